@@ -0,0 +1,112 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// BinaryAdapter binds a UUID as binary in a chosen BinaryMode, without
+// changing the Preferences of the UUID itself.
+type BinaryAdapter struct {
+	uuid UUID
+	mode BinaryMode
+}
+
+// TextAdapter binds a UUID as text in a chosen TextMode, without changing
+// the Preferences of the UUID itself.
+type TextAdapter struct {
+	uuid UUID
+	mode TextMode
+}
+
+// BinaryPtrAdapter scans into a UUID using a chosen BinaryMode, without
+// changing the Preferences of the UUID itself.
+type BinaryPtrAdapter struct {
+	uuid *UUID
+	mode BinaryMode
+}
+
+// TextPtrAdapter scans into a UUID as AsTextPtr describes, without changing
+// the Preferences of the UUID itself.
+type TextPtrAdapter struct {
+	uuid *UUID
+	mode TextMode
+}
+
+var _ driver.Valuer = BinaryAdapter{}
+var _ driver.Valuer = TextAdapter{}
+var _ sql.Scanner = BinaryPtrAdapter{}
+var _ sql.Scanner = TextPtrAdapter{}
+
+// AsBinary returns a driver.Valuer that binds uuid as binary in the given BinaryMode.
+func AsBinary(uuid UUID, mode BinaryMode) BinaryAdapter {
+	return BinaryAdapter{uuid: uuid, mode: mode}
+}
+
+// AsText returns a driver.Valuer that binds uuid as text in the given TextMode.
+func AsText(uuid UUID, mode TextMode) TextAdapter {
+	return TextAdapter{uuid: uuid, mode: mode}
+}
+
+// AsBinaryPtr returns a sql.Scanner that scans into *uuid, interpreting
+// binary input in the given BinaryMode.
+func AsBinaryPtr(uuid *UUID, mode BinaryMode) BinaryPtrAdapter {
+	return BinaryPtrAdapter{uuid: uuid, mode: mode}
+}
+
+// AsTextPtr returns a sql.Scanner that scans into *uuid.  Text input is
+// parsed in any format, so the TextMode only matters if the same adapter is
+// later used to bind the value again.
+func AsTextPtr(uuid *UUID, mode TextMode) TextPtrAdapter {
+	return TextPtrAdapter{uuid: uuid, mode: mode}
+}
+
+// UUID returns the adapted UUID.
+func (a BinaryAdapter) UUID() UUID {
+	return a.uuid
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (a BinaryAdapter) Value() (driver.Value, error) {
+	x := Preferences{Value: Binary, Binary: a.mode}.collapse(a.uuid.getBits())
+	return valueImpl(a.uuid.a[:], x), nil
+}
+
+// UUID returns the adapted UUID.
+func (a TextAdapter) UUID() UUID {
+	return a.uuid
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (a TextAdapter) Value() (driver.Value, error) {
+	pref := Preferences{Value: Text, Text: a.mode}
+	if a.mode != 0 {
+		pref.SQL = Inherit
+	}
+	x := pref.collapse(a.uuid.getBits())
+	return valueImpl(a.uuid.a[:], x), nil
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (a BinaryPtrAdapter) Value() (driver.Value, error) {
+	return AsBinary(*a.uuid, a.mode).Value()
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+func (a BinaryPtrAdapter) Scan(value interface{}) error {
+	x := Preferences{Value: Binary, Binary: a.mode}.collapse(a.uuid.getBits())
+	_, err := scanValue("BinaryPtrAdapter", "Scan", a.uuid.a[:], value, x)
+	return err
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (a TextPtrAdapter) Value() (driver.Value, error) {
+	return AsText(*a.uuid, a.mode).Value()
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+func (a TextPtrAdapter) Scan(value interface{}) error {
+	x := Preferences{Value: Text, Text: a.mode}.collapse(a.uuid.getBits())
+	_, err := scanValue("TextPtrAdapter", "Scan", a.uuid.a[:], value, x)
+	return err
+}
@@ -0,0 +1,63 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestAdapter(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	standardBytes := []byte{
+		0x77, 0xb9, 0x9c, 0xea,
+		0x8a, 0xb4, 0x11, 0xe8,
+		0x96, 0xa8, 0x18, 0x5e,
+		0x0f, 0xad, 0x63, 0x35,
+	}
+	denseBytes := []byte{
+		0x11, 0xe8, 0x8a, 0xb4,
+		0x77, 0xb9, 0x9c, 0xea,
+		0x96, 0xa8, 0x18, 0x5e,
+		0x0f, 0xad, 0x63, 0x35,
+	}
+
+	u := MustFromString(text)
+	u.SetPreferences(Preferences{Value: Text, Binary: StandardOnly, Text: Dense, NilAs: NilZero})
+	before := u.Preferences()
+
+	checkValue(t, "AsBinary DenseOnly", denseBytes, justValue(AsBinary(u, DenseOnly).Value()))
+	checkValue(t, "AsBinary StandardOnly", standardBytes, justValue(AsBinary(u, StandardOnly).Value()))
+	checkValue(t, "AsBinary inherited", standardBytes, justValue(AsBinary(u, 0).Value()))
+	checkValue(t, "AsText Canonical", text, justValue(AsText(u, Canonical).Value()))
+	checkValue(t, "AsText HashLike", "77b99cea8ab411e896a8185e0fad6335", justValue(AsText(u, HashLike).Value()))
+	checkValue(t, "Value", "@EeiKtHe5nOqWqBheD61jNQ", justValue(u.Value()))
+	checkEqual(t, "AsBinary.UUID", true, u, AsBinary(u, DenseOnly).UUID())
+	checkEqual(t, "AsText.UUID", true, u, AsText(u, Canonical).UUID())
+
+	var scanned UUID
+	scanned.SetPreferences(before)
+	if err := AsBinaryPtr(&scanned, DenseOnly).Scan(denseBytes); err != nil {
+		t.Errorf("failed to Scan: %v", err)
+	}
+	checkEqual(t, "AsBinaryPtr DenseOnly", true, u, scanned)
+	if actual := scanned.Preferences(); actual != before {
+		t.Errorf("AsBinaryPtr changed Preferences: expected %v, got %v", before, actual)
+	}
+
+	scanned = UUID{}
+	if err := AsBinaryPtr(&scanned, StandardOnly).Scan(denseBytes); err == nil {
+		t.Errorf("unexpected success at Scan: %s", scanned.CanonicalString())
+	}
+
+	scanned = UUID{}
+	if err := AsTextPtr(&scanned, Canonical).Scan(text); err != nil {
+		t.Errorf("failed to Scan: %v", err)
+	}
+	checkEqual(t, "AsTextPtr", true, u, scanned)
+	checkValue(t, "AsTextPtr.Value", text, justValue(AsTextPtr(&scanned, Canonical).Value()))
+	checkValue(t, "AsBinaryPtr.Value", denseBytes, justValue(AsBinaryPtr(&scanned, DenseOnly).Value()))
+
+	if err := AsTextPtr(&scanned, Canonical).Scan(42); err == nil {
+		t.Errorf("unexpected success at Scan of int")
+	} else if _, ok := err.(TypeError); !ok {
+		t.Errorf("expected TypeError, got %T", err)
+	}
+}
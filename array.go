@@ -0,0 +1,68 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// Array holds the elements of a PostgreSQL uuid[] or text[] column, with
+// NULL elements represented as invalid NullUUIDs.
+//
+// Unlike a List, every element of an Array carries its own Preferences.
+type Array []NullUUID
+
+var _ driver.Valuer = Array(nil)
+var _ sql.Scanner = (*Array)(nil)
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+// It produces a PostgreSQL array literal, e.g. `{a,b,NULL}`, holding the
+// textual representation of each element in that element's TextMode.
+func (arr Array) Value() (driver.Value, error) {
+	if arr == nil {
+		return nil, nil
+	}
+	value := marshalPGArray(len(arr), func(w *sliceWriter, i int) bool {
+		nu := arr[i]
+		if !nu.Valid {
+			return false
+		}
+		marshalText(w, nu.UUID.a[:], nu.UUID.getBits().forChannel(channelSQL))
+		return true
+	})
+	return value, nil
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+// It attempts to interpret a PostgreSQL array literal as an Array of UUIDs.
+// SQL NULL yields a nil Array.
+func (arr *Array) Scan(value interface{}) error {
+	var in []byte
+	switch v := value.(type) {
+	case nil:
+		*arr = nil
+		return nil
+	case []byte:
+		in = v
+	case string:
+		in = []byte(v)
+	default:
+		return makeTypeError("Array", "Scan", value, nil, []byte(nil), "")
+	}
+
+	items, err := parsePGArray("Array", "Scan", in)
+	if err != nil {
+		return err
+	}
+	out := make(Array, len(items))
+	for i, item := range items {
+		if item == nil {
+			continue
+		}
+		if err := unmarshalText("Array", "Scan", out[i].UUID.a[:], item, out[i].UUID.getBits()); err != nil {
+			return makeParseError("Array", "Scan", in, true).element(i, err)
+		}
+		out[i].Valid = true
+	}
+	*arr = out
+	return nil
+}
@@ -0,0 +1,61 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestArray(t *testing.T) {
+	u0 := MustFromString("11d3c015-c015-11d3-96a8-185e0fad6335")
+	u1 := MustFromString("77b99cea-8ab4-11e8-96a8-185e0fad6335")
+	u1.SetPreferences(Preferences{Text: Canonical})
+
+	arr := Array{{UUID: u0, Valid: true}, {}, {UUID: u1, Valid: true}}
+	output := `{@EdPAFRHTwBWWqBheD61jNQ,NULL,77b99cea-8ab4-11e8-96a8-185e0fad6335}`
+	checkValue(t, "Value", output, justValue(arr.Value()))
+	if v := justValue(Array(nil).Value()); v != nil {
+		t.Errorf("flubbed Value of nil Array: expected nil, got %#v", v)
+	}
+	checkValue(t, "Value of empty Array", `{}`, justValue(Array{}.Value()))
+
+	var parsed Array
+	if err := parsed.Scan(output); err != nil {
+		t.Fatalf("failed to Scan %q: %v", output, err)
+	}
+	if len(parsed) != 3 {
+		t.Fatalf("wrong length after Scan: expected 3, got %d", len(parsed))
+	}
+	checkEqual(t, "Scan[0]", true, u0, parsed[0].UUID)
+	checkEqual(t, "Scan[2]", true, u1, parsed[2].UUID)
+	if !parsed[0].Valid || parsed[1].Valid || !parsed[2].Valid {
+		t.Errorf("wrong Valid after Scan: got %v %v %v", parsed[0].Valid, parsed[1].Valid, parsed[2].Valid)
+	}
+
+	if err := parsed.Scan(nil); err != nil || parsed != nil {
+		t.Errorf("flubbed Scan of nil: got %v, %v", parsed, err)
+	}
+
+	type testrow struct {
+		input    interface{}
+		element  int
+		expected string
+	}
+	data := []testrow{
+		{`{@EdPAFRHTwBWWqBheD61jNQ,bogus}`, 1, `uuid.Array.Scan: failed to parse "{@EdPAFRHTwBWWqBheD61jNQ,bogus}": element 1: unexpected byte 'o' 0x6f at position 1, expected 0-9, A-F, a-f, or -`},
+		{`{@EdPAFRHTwBWWqBheD61jNQ`, -1, `uuid.Array.Scan: failed to parse "{@EdPAFRHTwBWWqBheD61jNQ": unexpected end of input at position 24, expected ',' or '}'`},
+		{42, -1, `uuid.Array.Scan: wrong type int: expected one of <nil> []uint8 string`},
+	}
+	for _, row := range data {
+		var arr Array
+		err := arr.Scan(row.input)
+		if err == nil {
+			t.Errorf("unexpected success at Scan %v", row.input)
+			continue
+		}
+		if actual := err.Error(); row.expected != actual {
+			t.Errorf("wrong error: expected %q, got %q", row.expected, actual)
+		}
+		if pe, ok := err.(ParseError); ok && pe.Element != row.element {
+			t.Errorf("wrong Element for %v: expected %d, got %d", row.input, row.element, pe.Element)
+		}
+	}
+}
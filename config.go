@@ -0,0 +1,250 @@
+package uuid
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+var _ encoding.TextMarshaler = Preferences{}
+var _ encoding.TextUnmarshaler = (*Preferences)(nil)
+var _ flag.Value = (*Preferences)(nil)
+
+var _ encoding.TextMarshaler = ValueMode(0)
+var _ encoding.TextUnmarshaler = (*ValueMode)(nil)
+var _ encoding.TextMarshaler = BinaryMode(0)
+var _ encoding.TextUnmarshaler = (*BinaryMode)(nil)
+var _ encoding.TextMarshaler = TextMode(0)
+var _ encoding.TextUnmarshaler = (*TextMode)(nil)
+var _ encoding.TextMarshaler = NilMode(0)
+var _ encoding.TextUnmarshaler = (*NilMode)(nil)
+var _ encoding.TextMarshaler = EchoMode(0)
+var _ encoding.TextUnmarshaler = (*EchoMode)(nil)
+var _ encoding.TextMarshaler = ValidationMode(0)
+var _ encoding.TextUnmarshaler = (*ValidationMode)(nil)
+
+// ParsePreferences parses a textual Preferences representation.  Two forms
+// are accepted:
+//
+//   - the compact form produced by Preferences.String, e.g. "TdDZ:III./R"
+//     or "TdD-:C-B+/A", in which the NilMode character, the overrides, Echo,
+//     and Validate may be omitted, e.g. "TdD", and
+//   - the form accepted by ApplyTags, e.g. "value=binary,binary=dense".
+//
+// Modes which are not mentioned, or which are given as '-', are left unset,
+// so that SetPreferences and SetDefaultPreferences leave them unchanged.
+func ParsePreferences(in string) (Preferences, error) {
+	pref, err := parsePreferences("", "ParsePreferences", []byte(in))
+	if err != nil {
+		return Preferences{}, err
+	}
+	return pref, nil
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+// It produces the compact form, e.g. "TdDZ:III./R".
+func (pref Preferences) MarshalText() ([]byte, error) {
+	str := pref.String()
+	if strings.IndexByte(str, '!') >= 0 {
+		return nil, fmt.Errorf("uuid: cannot marshal Preferences %q with unknown modes", str)
+	}
+	return []byte(str), nil
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+// It accepts the same forms as ParsePreferences.
+func (pref *Preferences) UnmarshalText(in []byte) error {
+	p, err := parsePreferences("Preferences", "UnmarshalText", in)
+	if err != nil {
+		return err
+	}
+	*pref = p
+	return nil
+}
+
+// Set fulfills the "flag".Value interface.
+// It accepts the same forms as ParsePreferences.
+func (pref *Preferences) Set(in string) error {
+	p, err := parsePreferences("Preferences", "Set", []byte(in))
+	if err != nil {
+		return err
+	}
+	*pref = p
+	return nil
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (vm ValueMode) MarshalText() ([]byte, error) {
+	return marshalMode(vmMap, vm, "ValueMode")
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+// It accepts either the one-character code or the name used by ApplyTags.
+func (vm *ValueMode) UnmarshalText(in []byte) error {
+	return unmarshalMode(vmMap, valueModeNames, vm, "ValueMode", in)
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (bm BinaryMode) MarshalText() ([]byte, error) {
+	return marshalMode(bmMap, bm, "BinaryMode")
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+// It accepts either the one-character code or the name used by ApplyTags.
+func (bm *BinaryMode) UnmarshalText(in []byte) error {
+	return unmarshalMode(bmMap, binaryModeNames, bm, "BinaryMode", in)
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (tm TextMode) MarshalText() ([]byte, error) {
+	return marshalMode(tmMap, tm, "TextMode")
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+// It accepts either the one-character code or the name used by ApplyTags.
+func (tm *TextMode) UnmarshalText(in []byte) error {
+	return unmarshalMode(tmMap, textModeNames, tm, "TextMode", in)
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (nm NilMode) MarshalText() ([]byte, error) {
+	return marshalMode(nmMap, nm, "NilMode")
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+// It accepts either the one-character code or the name used by ApplyTags.
+func (nm *NilMode) UnmarshalText(in []byte) error {
+	return unmarshalMode(nmMap, nilModeNames, nm, "NilMode", in)
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (em EchoMode) MarshalText() ([]byte, error) {
+	return marshalMode(emMap, em, "EchoMode")
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+// It accepts either the one-character code or the name used by ApplyTags.
+func (em *EchoMode) UnmarshalText(in []byte) error {
+	return unmarshalMode(emMap, echoModeNames, em, "EchoMode", in)
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (vm ValidationMode) MarshalText() ([]byte, error) {
+	return marshalMode(valMap, vm, "ValidationMode")
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+// It accepts either the one-character code or the name used by ApplyTags.
+func (vm *ValidationMode) UnmarshalText(in []byte) error {
+	return unmarshalMode(valMap, validationModeNames, vm, "ValidationMode", in)
+}
+
+func marshalMode[M ~byte](codes map[M]byte, mode M, typeName string) ([]byte, error) {
+	ch, found := codes[mode]
+	if !found {
+		return nil, fmt.Errorf("uuid: cannot marshal unknown value %s(%d)", typeName, mode)
+	}
+	return []byte{ch}, nil
+}
+
+func unmarshalMode[M ~byte](codes map[M]byte, names map[string]M, out *M, typeName string, in []byte) error {
+	if len(in) == 1 {
+		if mode, found := modeFromCode(codes, in[0]); found {
+			*out = mode
+			return nil
+		}
+	} else if mode, found := names[strings.ToLower(string(in))]; found {
+		*out = mode
+		return nil
+	}
+	err := failAt(makeParseError(typeName, "UnmarshalText", in, true), in, 0, typeName)
+	if len(in) != 0 {
+		err.Got = fmt.Sprintf("%q", in)
+	}
+	return err.detailf("unknown %s", typeName)
+}
+
+// failAt records a failure at pos, where expected was expected: the byte
+// at pos is bad or, if pos is the end of in, the input is too short.
+func failAt(err ParseError, in []byte, pos int, expected string) ParseError {
+	if pos >= len(in) {
+		return err.at(pos, KindTooShort, "end of input", expected)
+	}
+	return err.at(pos, KindBadByte, fmt.Sprintf("%q", in[pos]), expected)
+}
+
+func modeFromCode[M ~byte](codes map[M]byte, ch byte) (M, bool) {
+	for mode, code := range codes {
+		if code == ch {
+			return mode, true
+		}
+	}
+	return 0, false
+}
+
+func parsePreferences(typeName, methodName string, in []byte) (Preferences, error) {
+	var pref Preferences
+	fail := makeParseError(typeName, methodName, in, true)
+	if strings.IndexByte(string(in), '=') >= 0 {
+		return parseTag(fail, in)
+	}
+	modes := in
+	if i := strings.IndexByte(string(in), '/'); i >= 0 {
+		if i+1 >= len(in) {
+			return pref, failAt(fail, in, i+1, "ValidationMode").detailf("expected ValidationMode at position %d", i+1)
+		}
+		var found bool
+		if pref.Validate, found = modeFromCode(valMap, in[i+1]); !found || pref.Validate == 0 {
+			return pref, failAt(fail, in, i+1, "ValidationMode").detailf("unknown ValidationMode %q at position %d", in[i+1], i+1)
+		}
+		if rest := in[i+2:]; len(rest) != 0 {
+			err := fail.at(i+2, KindTrailingData, fmt.Sprintf("%q", rest[0]), "end of input")
+			return pref, err.detailf("unexpected %q after ValidationMode %v", rest, pref.Validate)
+		}
+		modes = in[:i]
+	}
+	if n := len(modes); n > 0 {
+		if em, found := modeFromCode(emMap, modes[n-1]); found && em != 0 {
+			modes = modes[:n-1]
+			pref.Echo = em
+		}
+	}
+	if n := len(modes); n != 3 && n != 4 && (n != 8 || modes[4] != ':') {
+		var err ParseError
+		switch {
+		case n > 4 && modes[4] != ':':
+			err = failAt(fail, in, 4, "':'")
+		case n < 3 || n > 4 && n < 8:
+			err = failAt(fail, in, n, "mode character")
+		default:
+			err = fail.at(8, KindTrailingData, fmt.Sprintf("%q", in[8]), "end of input")
+		}
+		return pref, err.detailf("expected 3, 4, or 8 mode characters, got %d", n)
+	}
+
+	var found bool
+	if pref.Value, found = modeFromCode(vmMap, in[0]); !found {
+		return pref, failAt(fail, in, 0, "ValueMode").detailf("unknown ValueMode %q at position 0", in[0])
+	}
+	if pref.Binary, found = modeFromCode(bmMap, in[1]); !found {
+		return pref, failAt(fail, in, 1, "BinaryMode").detailf("unknown BinaryMode %q at position 1", in[1])
+	}
+	if pref.Text, found = modeFromCode(tmMap, in[2]); !found || pref.Text == Inherit {
+		return pref, failAt(fail, in, 2, "TextMode").detailf("unknown TextMode %q at position 2", in[2])
+	}
+	if len(modes) >= 4 {
+		if pref.NilAs, found = modeFromCode(nmMap, in[3]); !found {
+			return pref, failAt(fail, in, 3, "NilMode").detailf("unknown NilMode %q at position 3", in[3])
+		}
+	}
+	if len(modes) == 8 {
+		overrides := []*TextMode{&pref.JSON, &pref.SQL, &pref.Fmt}
+		for i, out := range overrides {
+			if *out, found = modeFromCode(tmMap, in[5+i]); !found {
+				return pref, failAt(fail, in, 5+i, "TextMode").detailf("unknown TextMode %q at position %d", in[5+i], 5+i)
+			}
+		}
+	}
+	return pref, nil
+}
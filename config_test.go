@@ -0,0 +1,240 @@
+package uuid
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestParsePreferences(t *testing.T) {
+	type testrow struct {
+		input    string
+		expected Preferences
+		errText  string
+	}
+	data := []testrow{
+		{"TdD-", Preferences{Value: Text, Binary: DenseFirst, Text: Dense}, ""},
+		{"TdD", Preferences{Value: Text, Binary: DenseFirst, Text: Dense}, ""},
+		{"BQRN", Preferences{Value: Binary, Binary: SQLServerOnly, Text: Registry, NilAs: NilNull}, ""},
+		{"----", Preferences{}, ""},
+		{"TdDZ:C-I", Preferences{Value: Text, Binary: DenseFirst, Text: Dense, NilAs: NilZero, JSON: Canonical, Fmt: Inherit}, ""},
+		{"TdDZ:C-I+", Preferences{Value: Text, Binary: DenseFirst, Text: Dense, NilAs: NilZero, JSON: Canonical, Fmt: Inherit, Echo: EchoOn}, ""},
+		{"TdD+", Preferences{Value: Text, Binary: DenseFirst, Text: Dense, Echo: EchoOn}, ""},
+		{"TdDZ.", Preferences{Value: Text, Binary: DenseFirst, Text: Dense, NilAs: NilZero, Echo: EchoOff}, ""},
+		{"text=hashlike,echo=on", Preferences{Text: HashLike, Echo: EchoOn}, ""},
+		{"TdDZ/A", Preferences{Value: Text, Binary: DenseFirst, Text: Dense, NilAs: NilZero, Validate: AcceptAny}, ""},
+		{"TdDZ+/A", Preferences{Value: Text, Binary: DenseFirst, Text: Dense, NilAs: NilZero, Echo: EchoOn, Validate: AcceptAny}, ""},
+		{"----/R", Preferences{Validate: RejectInvalid}, ""},
+		{"validate=any", Preferences{Validate: AcceptAny}, ""},
+		{"TdDZ/X", Preferences{}, `uuid.ParsePreferences: failed to parse "TdDZ/X": unknown ValidationMode 'X' at position 5`},
+		{"TdDZ/A1", Preferences{}, `uuid.ParsePreferences: failed to parse "TdDZ/A1": unexpected "1" after ValidationMode A`},
+		{"TdDZ/L14", Preferences{}, `uuid.ParsePreferences: failed to parse "TdDZ/L14": unknown ValidationMode 'L' at position 5`},
+		{"validate=v1|v4", Preferences{}, `uuid.ParsePreferences: failed to parse "validate=v1|v4": unknown validate mode "v1|v4"`},
+		{"json=canonical,sql=dense,fmt=inherit", Preferences{JSON: Canonical, SQL: Dense, Fmt: Inherit}, ""},
+		{"TdI", Preferences{}, `uuid.ParsePreferences: failed to parse "TdI": unknown TextMode 'I' at position 2`},
+		{"TdDZ:CXB", Preferences{}, `uuid.ParsePreferences: failed to parse "TdDZ:CXB": unknown TextMode 'X' at position 6`},
+		{"text=inherit", Preferences{}, `uuid.ParsePreferences: failed to parse "text=inherit": unknown text mode "inherit"`},
+		{"value=binary,binary=dense", Preferences{Value: Binary, Binary: DenseOnly}, ""},
+		{"text=Canonical, nil=jsonnull", Preferences{Text: Canonical, NilAs: NilJSONNull}, ""},
+		{"", Preferences{}, `uuid.ParsePreferences: failed to parse "": expected 3, 4, or 8 mode characters, got 0`},
+		{"TdDZx", Preferences{}, `uuid.ParsePreferences: failed to parse "TdDZx": expected 3, 4, or 8 mode characters, got 5`},
+		{"XdD", Preferences{}, `uuid.ParsePreferences: failed to parse "XdD": unknown ValueMode 'X' at position 0`},
+		{"TxD", Preferences{}, `uuid.ParsePreferences: failed to parse "TxD": unknown BinaryMode 'x' at position 1`},
+		{"TdX", Preferences{}, `uuid.ParsePreferences: failed to parse "TdX": unknown TextMode 'X' at position 2`},
+		{"TdDX", Preferences{}, `uuid.ParsePreferences: failed to parse "TdDX": unknown NilMode 'X' at position 3`},
+		{"text=bogus", Preferences{}, `uuid.ParsePreferences: failed to parse "text=bogus": unknown text mode "bogus"`},
+	}
+	for _, row := range data {
+		t.Run(row.input, func(t *testing.T) {
+			actual, err := ParsePreferences(row.input)
+			switch {
+			case err != nil && row.errText == "":
+				t.Errorf("failed to ParsePreferences: %v", err)
+			case err == nil && row.errText != "":
+				t.Errorf("unexpected success at ParsePreferences: %v", actual)
+			case err != nil:
+				if msg := err.Error(); row.errText != msg {
+					t.Errorf("wrong error: expected %q, got %q", row.errText, msg)
+				}
+			case row.expected != actual:
+				t.Errorf("wrong Preferences: expected %v, got %v", row.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParsePreferences_ErrorKind(t *testing.T) {
+	type testrow struct {
+		input    string
+		kind     ErrorKind
+		position int
+	}
+	data := []testrow{
+		{"", KindTooShort, 0},
+		{"Td", KindTooShort, 2},
+		{"TdDZx", KindBadByte, 4},
+		{"TdDZ:CD", KindTooShort, 7},
+		{"TdDZ:CDBZZ", KindTrailingData, 8},
+		{"TxDZ", KindBadByte, 1},
+		{"TdDZ:CXB", KindBadByte, 6},
+		{"TdDZ/", KindTooShort, 5},
+		{"TdDZ/X", KindBadByte, 5},
+		{"TdDZ/A1", KindTrailingData, 6},
+		{"text=bogus", KindBadByte, 5},
+		{"colour=blue", KindBadByte, 0},
+		{"text=urn, text=dense", KindBadByte, 10},
+		{"text=urn,", KindTooShort, 9},
+	}
+	for _, row := range data {
+		_, err := ParsePreferences(row.input)
+		if err == nil {
+			t.Errorf("unexpected success at ParsePreferences %q", row.input)
+			continue
+		}
+		pe := err.(ParseError)
+		if pe.Kind != row.kind || pe.Position != row.position {
+			t.Errorf("wrong error for ParsePreferences %q: expected %v at %d, got %v at %d", row.input, row.kind, row.position, pe.Kind, pe.Position)
+		}
+		if !errors.Is(err, row.kind.Sentinel()) {
+			t.Errorf("errors.Is(%v, %v) is false", err, row.kind.Sentinel())
+		}
+	}
+
+	var tm TextMode
+	if err := tm.UnmarshalText([]byte("base64")); !errors.Is(err, ErrBadByte) {
+		t.Errorf("wrong error for TextMode.UnmarshalText: %v", err)
+	}
+}
+
+func TestPreferences_Text(t *testing.T) {
+	pref := Preferences{Value: Binary, Binary: GUIDOnly, Text: URN, NilAs: NilEmpty}
+	text := justBytes(pref.MarshalText())
+	checkText(t, "MarshalText", "BGUE", text)
+
+	anyVersion := Preferences{Text: Canonical, Validate: AcceptAny}
+	checkText(t, "MarshalText with AcceptAny", "--C-/A", justBytes(anyVersion.MarshalText()))
+
+	var parsed Preferences
+	if err := parsed.UnmarshalText(text); err != nil {
+		t.Errorf("failed to UnmarshalText: %v", err)
+	}
+	if parsed != pref {
+		t.Errorf("wrong round trip: expected %v, got %v", pref, parsed)
+	}
+
+	inherit := Preferences{JSON: Inherit, SQL: Inherit, Fmt: Inherit}
+	checkText(t, "MarshalText with Inherit", "----:III", justBytes(inherit.MarshalText()))
+
+	echoOff := Preferences{Echo: EchoOff}
+	checkText(t, "MarshalText with EchoOff", "----.", justBytes(echoOff.MarshalText()))
+	parsed = Preferences{}
+	if err := parsed.UnmarshalText(justBytes(echoOff.MarshalText())); err != nil {
+		t.Errorf("failed to UnmarshalText: %v", err)
+	} else if parsed != echoOff {
+		t.Errorf("wrong round trip: expected %v, got %v", echoOff, parsed)
+	}
+
+	if _, err := (Preferences{Binary: BinaryMode(42)}).MarshalText(); err == nil {
+		t.Errorf("unexpected success at MarshalText of unknown BinaryMode")
+	}
+
+	type config struct {
+		Pref   Preferences
+		Value  ValueMode
+		Binary BinaryMode
+		Text   TextMode
+		NilAs  NilMode
+	}
+	in := `{"Pref":"TsC-","Value":"binary","Binary":"d","Text":"urn","NilAs":"N"}`
+	var cfg config
+	if err := json.Unmarshal([]byte(in), &cfg); err != nil {
+		t.Fatalf("failed to json.Unmarshal: %v", err)
+	}
+	expected := config{
+		Pref:   Preferences{Value: Text, Binary: StandardFirst, Text: Canonical},
+		Value:  Binary,
+		Binary: DenseFirst,
+		Text:   URN,
+		NilAs:  NilNull,
+	}
+	if cfg != expected {
+		t.Errorf("wrong json.Unmarshal: expected %+v, got %+v", expected, cfg)
+	}
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("failed to json.Marshal: %v", err)
+	}
+	checkText(t, "json.Marshal", `{"Pref":"TsC-","Value":"B","Binary":"d","Text":"U","NilAs":"N"}`, out)
+
+	var tm TextMode
+	err = tm.UnmarshalText([]byte("base64"))
+	if msg := errString(err); msg != `uuid.TextMode.UnmarshalText: failed to parse "base64": unknown TextMode` {
+		t.Errorf("wrong error: got %q", msg)
+	}
+}
+
+func TestPreferences_RoundTrip(t *testing.T) {
+	data := []Preferences{
+		{},
+		DefaultPreferences(),
+		{Echo: EchoOff},
+		{Validate: RejectInvalid},
+		{Value: Binary, Binary: GUIDOnly, Text: Registry, NilAs: NilJSONNull, JSON: Canonical, Echo: EchoOn, Validate: AcceptAny},
+		{Text: URN, SQL: Inherit, Echo: EchoOff, Validate: RejectInvalid},
+	}
+	for _, pref := range data {
+		t.Run(pref.String(), func(t *testing.T) {
+			text, err := pref.MarshalText()
+			if err != nil {
+				t.Fatalf("failed to MarshalText: %v", err)
+			}
+			var parsed Preferences
+			if err := parsed.UnmarshalText(text); err != nil {
+				t.Fatalf("failed to UnmarshalText %q: %v", text, err)
+			}
+			if parsed != pref {
+				t.Errorf("wrong round trip through %q: expected %+v, got %+v", text, pref, parsed)
+			}
+		})
+	}
+
+	// The compact form can switch a UUID back to the defaults.
+	u := Preferences{Echo: EchoOn, Validate: AcceptAny}.Nil()
+	if str := DefaultPreferences().String(); str != "TdDZ:III./R" {
+		t.Errorf("wrong DefaultPreferences().String(): expected %q, got %q", "TdDZ:III./R", str)
+	}
+	pref, err := ParsePreferences(DefaultPreferences().String())
+	if err != nil {
+		t.Fatalf("failed to ParsePreferences: %v", err)
+	}
+	u.SetPreferences(pref)
+	if actual := u.Preferences(); actual != DefaultPreferences() {
+		t.Errorf("wrong Preferences after SetPreferences: expected %v, got %v", DefaultPreferences(), actual)
+	}
+}
+
+func TestPreferences_Flag(t *testing.T) {
+	pref := Preferences{Text: Canonical}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&pref, "uuid", "UUID preferences")
+
+	if err := fs.Parse([]string{"-uuid", "BDH-"}); err != nil {
+		t.Fatalf("failed to Parse: %v", err)
+	}
+	if expected := (Preferences{Value: Binary, Binary: DenseOnly, Text: HashLike}); pref != expected {
+		t.Errorf("wrong Preferences: expected %v, got %v", expected, pref)
+	}
+	if err := fs.Parse([]string{"-uuid", "bogus"}); err == nil {
+		t.Errorf("unexpected success at Parse")
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
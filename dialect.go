@@ -0,0 +1,83 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Dialect selects the SQL dialect in which SQLLiteral renders a UUID.
+type Dialect byte
+
+// Dialect enum constants.
+const (
+	_ Dialect = iota
+
+	// Postgres: text as `'...'::uuid` when PostgreSQL's uuid type accepts
+	// the TextMode or `'...'` otherwise, binary as `'\x...'::bytea`.
+	Postgres
+
+	// MySQL: text as `'...'`, binary as `UNHEX('...')`.
+	MySQL
+
+	// SQLite: text as `'...'`, binary as `X'...'`.
+	SQLite
+
+	// SQLServer: text as `'...'`, binary as `0x...`.
+	SQLServer
+)
+
+var dialectMap = map[Dialect]string{
+	Postgres:  "Postgres",
+	MySQL:     "MySQL",
+	SQLite:    "SQLite",
+	SQLServer: "SQLServer",
+}
+
+func (d Dialect) String() string {
+	if str, found := dialectMap[d]; found {
+		return str
+	}
+	return fmt.Sprintf("Dialect(%d)", d)
+}
+
+// sqlLiteral renders the output of valueImpl as a SQL literal in the given
+// Dialect.  The bits decide whether PostgreSQL can cast the text to uuid.
+func sqlLiteral(value driver.Value, x bits, d Dialect) string {
+	if _, found := dialectMap[d]; !found {
+		panic(fmt.Errorf("unknown value Dialect(%d)", d))
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+
+	case []byte:
+		digits := hex.EncodeToString(v)
+		switch d {
+		case Postgres:
+			return `'\x` + digits + `'::bytea`
+		case MySQL:
+			return `UNHEX('` + digits + `')`
+		case SQLite:
+			return `X'` + digits + `'`
+		default:
+			return `0x` + digits
+		}
+
+	case string:
+		quoted := "'" + strings.ReplaceAll(v, "'", "''") + "'"
+		if d == Postgres && v != "" && isPostgresUUIDText(x) {
+			return quoted + "::uuid"
+		}
+		return quoted
+	}
+	panic(fmt.Errorf("unexpected driver.Value type %T", value))
+}
+
+// isPostgresUUIDText returns true iff PostgreSQL's uuid input function
+// accepts the textual representation selected by x.
+func isPostgresUUIDText(x bits) bool {
+	return !x.has(bitTextIsDense) && x.just(bitsTextIsMode) != textModeURN
+}
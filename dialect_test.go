@@ -0,0 +1,66 @@
+package uuid
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUUID_SQLLiteral(t *testing.T) {
+	u := MustFromString("77b99cea-8ab4-11e8-96a8-185e0fad6335")
+
+	canonical := Preferences{Value: Text, Text: Canonical}
+	dense := Preferences{Value: Text, Text: Dense}
+	urn := Preferences{Value: Text, Text: URN}
+	standardBlob := Preferences{Value: Binary, Binary: StandardOnly}
+	denseBlob := Preferences{Value: Binary, Binary: DenseOnly}
+
+	type testrow struct {
+		pref     Preferences
+		dialect  Dialect
+		expected string
+	}
+	data := []testrow{
+		{canonical, Postgres, `'77b99cea-8ab4-11e8-96a8-185e0fad6335'::uuid`},
+		{canonical, MySQL, `'77b99cea-8ab4-11e8-96a8-185e0fad6335'`},
+		{canonical, SQLite, `'77b99cea-8ab4-11e8-96a8-185e0fad6335'`},
+		{canonical, SQLServer, `'77b99cea-8ab4-11e8-96a8-185e0fad6335'`},
+		{dense, Postgres, `'@EeiKtHe5nOqWqBheD61jNQ'`},
+		{dense, MySQL, `'@EeiKtHe5nOqWqBheD61jNQ'`},
+		{urn, Postgres, `'urn:uuid:77b99cea-8ab4-11e8-96a8-185e0fad6335'`},
+		{standardBlob, Postgres, `'\x77b99cea8ab411e896a8185e0fad6335'::bytea`},
+		{standardBlob, MySQL, `UNHEX('77b99cea8ab411e896a8185e0fad6335')`},
+		{standardBlob, SQLite, `X'77b99cea8ab411e896a8185e0fad6335'`},
+		{standardBlob, SQLServer, `0x77b99cea8ab411e896a8185e0fad6335`},
+		{denseBlob, MySQL, `UNHEX('11e88ab477b99cea96a8185e0fad6335')`},
+		{denseBlob, SQLite, `X'11e88ab477b99cea96a8185e0fad6335'`},
+	}
+	for _, row := range data {
+		name := fmt.Sprintf("%s/%s", row.pref, row.dialect)
+		t.Run(name, func(t *testing.T) {
+			u := u
+			u.SetPreferences(row.pref)
+			checkString(t, "SQLLiteral", row.expected, u.SQLLiteral(row.dialect))
+		})
+	}
+
+	var nilUUID UUID
+	nilUUID.SetPreferences(Preferences{Value: Text, Text: Canonical, NilAs: NilNull})
+	checkString(t, "SQLLiteral of Nil", `NULL`, nilUUID.SQLLiteral(Postgres))
+	nilUUID.SetPreferences(Preferences{NilAs: NilEmpty})
+	checkString(t, "SQLLiteral of Nil", `''`, nilUUID.SQLLiteral(Postgres))
+
+	checkString(t, "NullUUID.SQLLiteral", `NULL`, NullUUID{UUID: u}.SQLLiteral(SQLite))
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("SQLLiteral of Dialect(42) did not panic")
+			}
+		}()
+		u.SQLLiteral(Dialect(42))
+	}()
+
+	if name := Dialect(42).String(); name != "Dialect(42)" {
+		t.Errorf("wrong String: expected %q, got %q", "Dialect(42)", name)
+	}
+}
@@ -1,17 +1,114 @@
 package uuid
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 )
 
+// ErrorKind classifies a ParseError.
+type ErrorKind byte
+
+// ErrorKind enum constants.
+const (
+	_ ErrorKind = iota
+
+	// KindBadByte: the input holds a byte that is not allowed at Position.
+	KindBadByte
+
+	// KindTooShort: the input ends before a complete UUID.
+	KindTooShort
+
+	// KindTrailingData: the input continues after a complete UUID.
+	KindTrailingData
+
+	// KindBadVersion: the UUID has a Version that is not accepted.
+	KindBadVersion
+
+	// KindBadVariant: the UUID has a Variant that is not accepted.
+	KindBadVariant
+
+	// KindBadFormat: the input is in a format that a Parser does not accept,
+	// or is a TypeID with the wrong prefix.
+	KindBadFormat
+
+	// KindRejected: the UUID is the Nil or Max UUID, which a Parser rejects,
+	// or a NULL element, which a List rejects.
+	KindRejected
+
+	// KindBadJSON: the input is not valid JSON.
+	KindBadJSON
+)
+
+// Sentinel errors matching each ErrorKind, for use with errors.Is.
+var (
+	ErrBadByte      = errors.New("uuid: unexpected byte")
+	ErrTooShort     = errors.New("uuid: unexpected end of input")
+	ErrTrailingData = errors.New("uuid: unexpected data at end of input")
+	ErrBadVersion   = errors.New("uuid: version not accepted")
+	ErrBadVariant   = errors.New("uuid: variant not accepted")
+	ErrBadFormat    = errors.New("uuid: format not accepted")
+	ErrRejected     = errors.New("uuid: value rejected")
+	ErrBadJSON      = errors.New("uuid: invalid JSON")
+)
+
+var ekMap = map[ErrorKind]string{
+	KindBadByte:      "KindBadByte",
+	KindTooShort:     "KindTooShort",
+	KindTrailingData: "KindTrailingData",
+	KindBadVersion:   "KindBadVersion",
+	KindBadVariant:   "KindBadVariant",
+	KindBadFormat:    "KindBadFormat",
+	KindRejected:     "KindRejected",
+	KindBadJSON:      "KindBadJSON",
+}
+
+var ekSentinels = map[ErrorKind]error{
+	KindBadByte:      ErrBadByte,
+	KindTooShort:     ErrTooShort,
+	KindTrailingData: ErrTrailingData,
+	KindBadVersion:   ErrBadVersion,
+	KindBadVariant:   ErrBadVariant,
+	KindBadFormat:    ErrBadFormat,
+	KindRejected:     ErrRejected,
+	KindBadJSON:      ErrBadJSON,
+}
+
+func (kind ErrorKind) String() string {
+	if str, found := ekMap[kind]; found {
+		return str
+	}
+	return fmt.Sprintf("ErrorKind(%d)", kind)
+}
+
+// Sentinel returns the sentinel error matching this ErrorKind, or nil.
+func (kind ErrorKind) Sentinel() error {
+	return ekSentinels[kind]
+}
+
 // ParseError represents an error in the contents of the input while parsing.
+//
+// Kind, Position, Got, and Expected describe the failure for programmatic
+// use; Detail describes it for humans.  Position is the byte offset into
+// ExactInput at which the failure was found, or -1 if the failure is not
+// tied to one offset.  Element is the index of the failing element when
+// parsing a collection such as an Array, List, or Set, or -1 otherwise.
+// Got and Expected are empty if they do not apply.
+//
+// errors.Is reports whether a ParseError matches the sentinel error for its
+// Kind, e.g. ErrBadByte, or the underlying error in Err.
 type ParseError struct {
 	TypeName   string
 	MethodName string
 	Input      string
 	Detail     string
 	ExactInput []byte
+	Kind       ErrorKind
+	Position   int
+	Element    int
+	Got        string
+	Expected   string
+	Err        error
 }
 
 var _ error = ParseError{}
@@ -28,6 +125,8 @@ func makeParseError(typeName, methodName string, input []byte, isText bool) Pars
 		MethodName: methodName,
 		Input:      mangled,
 		ExactInput: copyBytes(input),
+		Position:   -1,
+		Element:    -1,
 	}
 }
 
@@ -36,6 +135,46 @@ func (err ParseError) detailf(formatString string, args ...interface{}) ParseErr
 	return err
 }
 
+// at records the structured description of the failure.  Pass -1 for a
+// failure that is not tied to one offset.
+func (err ParseError) at(pos int, kind ErrorKind, got, expected string) ParseError {
+	err.Position = pos
+	err.Kind = kind
+	err.Got = got
+	err.Expected = expected
+	return err
+}
+
+func (err ParseError) wrap(cause error) ParseError {
+	err.Err = cause
+	return err
+}
+
+// element describes the failure to parse element i of a collection, with
+// the Kind, Got, Expected, and Err of inner.  Position is not kept, because
+// it is relative to the element.
+func (err ParseError) element(i int, inner error) ParseError {
+	err.Element = i
+	pe, ok := inner.(ParseError)
+	if !ok {
+		return err.detailf("element %d: %v", i, inner).wrap(inner)
+	}
+	err = err.at(-1, pe.Kind, pe.Got, pe.Expected).wrap(pe.Err)
+	return err.detailf("element %d: %s", i, pe.Detail)
+}
+
+// Is returns true iff target is the sentinel error for this ParseError's Kind.
+func (err ParseError) Is(target error) bool {
+	sentinel := err.Kind.Sentinel()
+	return sentinel != nil && target == sentinel
+}
+
+// Unwrap returns the underlying error, such as the failure of json.Unmarshal
+// within UnmarshalJSON, or nil.
+func (err ParseError) Unwrap() error {
+	return err.Err
+}
+
 func (err ParseError) Error() string {
 	w := makeSliceWriter(bufferLength)
 	defer w.release()
@@ -109,6 +248,10 @@ func (err TypeError) Error() string {
 		w.WriteString(": expected one of")
 		for _, t := range err.ExpectedTypes {
 			w.WriteString(" ")
+			if t == nil {
+				w.WriteString("<nil>")
+				continue
+			}
 			w.WriteString(t.String())
 		}
 	}
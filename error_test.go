@@ -1,6 +1,8 @@
 package uuid
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -128,6 +130,14 @@ func TestTypeError(t *testing.T) {
 			dummies:    []interface{}{new(bool), new(string), []byte(nil), &TypeError{}},
 			expected:   `uuid.Class.Func: wrong type uint64: expected one of *bool *string []uint8 *uuid.TypeError`,
 		},
+		{
+			testName:   "nil dummy",
+			typeName:   "Class",
+			methodName: "Func",
+			input:      uint64(0),
+			dummies:    []interface{}{nil, []byte(nil)},
+			expected:   `uuid.Class.Func: wrong type uint64: expected one of <nil> []uint8`,
+		},
 	}
 
 	for _, row := range data {
@@ -140,3 +150,148 @@ func TestTypeError(t *testing.T) {
 		})
 	}
 }
+
+func TestParseError_Kind(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+
+	type testrow struct {
+		testName string
+		parse    func() error
+		kind     ErrorKind
+		sentinel error
+		position int
+		got      string
+		expected string
+	}
+	data := []testrow{
+		{
+			testName: "bad byte",
+			parse:    func() error { _, err := FromString("77b99cex"); return err },
+			kind:     KindBadByte,
+			sentinel: ErrBadByte,
+			position: 7,
+			got:      "'x'",
+			expected: "0-9, A-F, a-f, or -",
+		},
+		{
+			testName: "too short",
+			parse:    func() error { _, err := FromString(text[:30]); return err },
+			kind:     KindTooShort,
+			sentinel: ErrTooShort,
+			position: 30,
+			got:      "end of input",
+			expected: "6 more hex digits",
+		},
+		{
+			testName: "trailing data",
+			parse:    func() error { _, err := FromString(text + "0"); return err },
+			kind:     KindTrailingData,
+			sentinel: ErrTrailingData,
+			position: 36,
+			got:      "'0'",
+			expected: "end of input",
+		},
+		{
+			testName: "dense trailing bits",
+			parse:    func() error { _, err := FromString("@EeiKtHe5nOqWqBheD61jNR"); return err },
+			kind:     KindTrailingData,
+			sentinel: ErrTrailingData,
+			position: 22,
+			got:      "NRAA",
+			expected: "NQAA",
+		},
+		{
+			testName: "short bytes",
+			parse:    func() error { _, err := FromBytes([]byte{1, 2, 3}); return err },
+			kind:     KindTooShort,
+			sentinel: ErrTooShort,
+			position: 3,
+			got:      "3 bytes",
+			expected: "16 bytes",
+		},
+		{
+			testName: "bad version",
+			parse:    func() error { _, err := FromString("77b99cea-8ab4-01e8-96a8-185e0fad6335"); return err },
+			kind:     KindBadVersion,
+			sentinel: ErrBadVersion,
+			position: -1,
+			got:      "V0",
+			expected: "version V1-V8",
+		},
+		{
+			testName: "bad variant",
+			parse:    func() error { _, err := FromString("77b99cea-8ab4-11e8-f6a8-185e0fad6335"); return err },
+			kind:     KindBadVariant,
+			sentinel: ErrBadVariant,
+			position: -1,
+			got:      "VariantFuture",
+			expected: "VariantRFC4122",
+		},
+		{
+			testName: "bad format",
+			parse:    func() error { _, err := Parser{Formats: []TextMode{Dense}}.FromString(text); return err },
+			kind:     KindBadFormat,
+			sentinel: ErrBadFormat,
+			position: 0,
+			got:      "canonical",
+			expected: "dense",
+		},
+		{
+			testName: "rejected",
+			parse:    func() error { _, err := Parser{RejectMax: true}.FromString(Max().CanonicalString()); return err },
+			kind:     KindRejected,
+			sentinel: ErrRejected,
+			position: -1,
+			got:      "Max UUID",
+		},
+		{
+			testName: "list element",
+			parse:    func() error { var list List; return list.Scan("{" + text + "," + text[:30] + "}") },
+			kind:     KindTooShort,
+			sentinel: ErrTooShort,
+			position: -1,
+			got:      "end of input",
+			expected: "6 more hex digits",
+		},
+	}
+	for _, row := range data {
+		t.Run(row.testName, func(t *testing.T) {
+			err := row.parse()
+			var pe ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("expected ParseError, got %T %v", err, err)
+			}
+			if pe.Kind != row.kind {
+				t.Errorf("wrong Kind: expected %v, got %v", row.kind, pe.Kind)
+			}
+			if !errors.Is(err, row.sentinel) {
+				t.Errorf("errors.Is(%v) is false", row.sentinel)
+			}
+			if errors.Is(err, ErrBadJSON) {
+				t.Errorf("errors.Is(ErrBadJSON) is true")
+			}
+			if pe.Position != row.position {
+				t.Errorf("wrong Position: expected %d, got %d", row.position, pe.Position)
+			}
+			if pe.Got != row.got {
+				t.Errorf("wrong Got: expected %q, got %q", row.got, pe.Got)
+			}
+			if pe.Expected != row.expected {
+				t.Errorf("wrong Expected: expected %q, got %q", row.expected, pe.Expected)
+			}
+		})
+	}
+
+	var u UUID
+	err := u.UnmarshalJSON([]byte(`"unterminated`))
+	if !errors.Is(err, ErrBadJSON) {
+		t.Errorf("errors.Is(ErrBadJSON) is false for %v", err)
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("errors.As(*json.SyntaxError) is false for %v", err)
+	}
+	if s := KindBadByte.String(); s != "KindBadByte" {
+		t.Errorf("wrong String: got %q", s)
+	}
+}
@@ -11,10 +11,10 @@ type formatDetails struct {
 	second      bits
 }
 
-func formatStudy(verb rune, hasPlus, hasSharp, hasMinus, hasWidth bool, width uint, x bits) formatDetails {
+func formatStudy(verb rune, hasPlus, hasSharp, hasMinus, hasWidth bool, width uint, x bits, isNil bool) formatDetails {
 	var d formatDetails
 
-	y := x.just(bitValid | bitTextIsDense | bitTextIsModeY | bitTextIsModeX)
+	y := x.forChannel(channelFmt).just(bitValid | bitsText | bitsNil)
 	switch verb {
 	case 'd':
 		d.first = bitValid | bitTextIsDense
@@ -58,6 +58,10 @@ func formatStudy(verb rune, hasPlus, hasSharp, hasMinus, hasWidth bool, width ui
 	}
 
 	add := func(x bits) {
+		if isNil && x.just(bitsNil) == nilModeEmpty {
+			// marshalText writes nothing at all.
+			return
+		}
 		used, peak := x.textLength()
 		need(peak)
 		use(used)
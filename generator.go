@@ -20,6 +20,8 @@ var gReader io.Reader = rand.Reader
 var gStateOnce sync.Once
 var gState *state
 
+var gStateV7 stateV7
+
 func globalState() *state {
 	gStateOnce.Do(func() {
 		s := systemSequence()
@@ -83,6 +85,77 @@ func (state *state) generate(out []byte) {
 	state.sequence = s
 	state.mu.Unlock()
 
+	writeV1(out, t, s, a)
+}
+
+// generateSequential is like generate, except that it never reuses a tick:
+// if the clock has not advanced past the last tick, the last tick plus one
+// is used instead.  The sequence is left untouched, so the results are
+// strictly increasing in DenseOrder.
+func (state *state) generateSequential(out []byte) {
+	state.mu.Lock()
+	t := state.tickFunc()
+	t0 := state.lastTick
+	s := state.sequence
+	a := state.address
+	if t0 <= tickMask && !isLess(t0, t) {
+		t = (t0 + 1) & tickMask
+	}
+	state.lastTick = t
+	state.mu.Unlock()
+
+	writeV1(out, t, s, a)
+}
+
+// stateV7 keeps V7 UUIDs generated by this process in increasing order: a
+// UUID generated within the same millisecond as the last one, or while the
+// clock is behind it, takes the last millisecond and the next counter value.
+type stateV7 struct {
+	mu      sync.Mutex
+	lastMS  uint64
+	counter uint16
+}
+
+const msMask = (1 << 48) - 1
+
+const counterMask = (1 << 12) - 1
+
+func (state *stateV7) generate(out []byte) {
+	var r [10]byte
+	mustReadRandom(r[:])
+
+	ms := uint64(gNow().UnixNano()/int64(time.Millisecond)) & msMask
+	// Start each millisecond in the lower half, leaving room to count up.
+	c := binary.BigEndian.Uint16(r[0:2]) & (counterMask >> 1)
+
+	state.mu.Lock()
+	if ms <= state.lastMS {
+		ms = state.lastMS
+		c = state.counter + 1
+		if c > counterMask {
+			ms = (ms + 1) & msMask
+			c = 0
+		}
+	}
+	state.lastMS = ms
+	state.counter = c
+	state.mu.Unlock()
+
+	writeV7(out, ms, c, r[2:10])
+}
+
+func writeV7(out []byte, ms uint64, c uint16, r []byte) {
+	var u64 [8]byte
+	binary.BigEndian.PutUint64(u64[:], ms)
+
+	copy(out[0:6], u64[2:8])
+	out[6] = byte(c>>8) | 0x70 // force V7
+	out[7] = byte(c)
+	out[8] = (r[0] & 0x3f) | 0x80 // force VariantRFC4122
+	copy(out[9:16], r[1:8])
+}
+
+func writeV1(out []byte, t uint64, s uint16, a [6]byte) {
 	var u64 [8]byte
 	binary.BigEndian.PutUint64(u64[:], t)
 
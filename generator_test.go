@@ -318,6 +318,84 @@ func TestGenerate(t *testing.T) {
 	test("31bc4003-7f1d-11e8-bfff-aabbccddeeff")
 }
 
+func TestGenerateSequential(t *testing.T) {
+	var clock uint64 = tickEpoch + 15306624000000000
+	f := func() uint64 { return clock }
+	s := uint16(0x3ffe)
+	a := [6]byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+
+	format := func(in [ByteLength]byte) string {
+		var out [36]byte
+		w := sliceWriter{slice: out[:], i: 0, j: 36}
+		marshalTextCanonical(&w, in[:])
+		return w.String()
+	}
+
+	state := newState(f, s, a)
+
+	test := func(expected string) {
+		var buf [ByteLength]byte
+		state.generateSequential(buf[:])
+		actual := format(buf)
+		if expected != actual {
+			t.Errorf("expected %s, got %s", expected, actual)
+		}
+	}
+
+	test("31bc4000-7f1d-11e8-bffe-aabbccddeeff")
+	test("31bc4001-7f1d-11e8-bffe-aabbccddeeff")
+	test("31bc4002-7f1d-11e8-bffe-aabbccddeeff")
+	clock++
+	test("31bc4003-7f1d-11e8-bffe-aabbccddeeff")
+	clock += 5
+	test("31bc4006-7f1d-11e8-bffe-aabbccddeeff")
+	clock -= 3
+	test("31bc4007-7f1d-11e8-bffe-aabbccddeeff")
+
+	// generate and generateSequential share their state
+	var buf [ByteLength]byte
+	state.generate(buf[:])
+	if expected, actual := "31bc4007-7f1d-11e8-bfff-aabbccddeeff", format(buf); expected != actual {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+	test("31bc4008-7f1d-11e8-bfff-aabbccddeeff")
+}
+
+func TestGenerateV7(t *testing.T) {
+	saved := gNow
+	defer func() { gNow = saved }()
+	clock := time.Unix(1514764800, 0)
+	gNow = func() time.Time { return clock }
+
+	var state stateV7
+	var last UUID
+	for i := 0; i < 3*(counterMask+1); i++ {
+		switch i % 1000 {
+		case 0:
+			clock = clock.Add(-time.Millisecond)
+		case 500:
+			clock = clock.Add(2 * time.Millisecond)
+		}
+		var uuid UUID
+		state.generate(uuid.a[:])
+		if version, variant := uuid.VersionAndVariant(); version != V7 || variant != VariantRFC4122 {
+			t.Fatalf("wrong version and variant: %v %v", version, variant)
+		}
+		if i > 0 && StandardOrder.Compare(last, uuid) >= 0 {
+			t.Fatalf("%v generated after %v", uuid, last)
+		}
+		last = uuid
+	}
+
+	// The timestamp leads, in milliseconds since the Unix epoch.
+	clock = time.Unix(1700000000, 0)
+	var uuid UUID
+	state.generate(uuid.a[:])
+	if expected, actual := "018bcfe5-6800", uuid.CanonicalString()[:13]; expected != actual {
+		t.Errorf("wrong timestamp: expected %s, got %s", expected, actual)
+	}
+}
+
 func TestIsSuitable(t *testing.T) {
 	type testrow struct {
 		input    []byte
@@ -1,3 +1,3 @@
 module github.com/team-spectre/go-uuid
 
-go 1.12
+go 1.18
@@ -0,0 +1,202 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// IDKind may optionally be implemented by the type parameter of ID, in order
+// to supply default Preferences for every ID of that kind.
+//
+//	type userKind struct{}
+//
+//	func (userKind) Preferences() uuid.Preferences {
+//		return uuid.Preferences{Value: uuid.Binary, Binary: uuid.DenseOnly}
+//	}
+//
+//	type UserID = uuid.ID[userKind]
+type IDKind interface {
+	Preferences() Preferences
+}
+
+// ID holds a UUID identifying an entity of kind T.  T is a phantom type that
+// is never instantiated; it only serves to make IDs of different kinds
+// distinct types, so that mixing them up is a compile-time error.
+type ID[T any] struct {
+	uuid UUID
+}
+
+var _ fmt.Stringer = ID[struct{}]{}
+var _ fmt.GoStringer = ID[struct{}]{}
+var _ fmt.Formatter = ID[struct{}]{}
+
+var _ encoding.BinaryMarshaler = ID[struct{}]{}
+var _ encoding.BinaryUnmarshaler = (*ID[struct{}])(nil)
+
+var _ encoding.TextMarshaler = ID[struct{}]{}
+var _ encoding.TextUnmarshaler = (*ID[struct{}])(nil)
+
+var _ json.Marshaler = ID[struct{}]{}
+var _ json.Unmarshaler = (*ID[struct{}])(nil)
+
+var _ driver.Valuer = ID[struct{}]{}
+var _ sql.Scanner = (*ID[struct{}])(nil)
+
+// NewID returns a newly generated V1 UUID of kind T.
+func NewID[T any]() ID[T] {
+	var id ID[T]
+	id.uuid = id.resolve()
+	id.uuid.SetNew()
+	return id
+}
+
+// IDFrom converts a UUID into an ID of kind T.
+func IDFrom[T any](uuid UUID) ID[T] {
+	return ID[T]{uuid: uuid}
+}
+
+// ParseID attempts to parse a textual UUID representation as an ID of kind T.
+func ParseID[T any](in string) (ID[T], error) {
+	var id ID[T]
+	id.uuid = id.resolve()
+	err := id.uuid.unmarshalText("ID", "ParseID", []byte(in))
+	return id, err
+}
+
+// MustParseID parses a textual UUID representation as an ID of kind T, or panics if it cannot.
+func MustParseID[T any](in string) ID[T] {
+	var id ID[T]
+	id.uuid = id.resolve()
+	err := id.uuid.unmarshalText("ID", "MustParseID", []byte(in))
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// resolve returns the held UUID, applying the Preferences supplied by T if
+// the UUID has none of its own.
+func (id ID[T]) resolve() UUID {
+	uuid := id.uuid
+	if !uuid.b.unpack().has(bitValid) {
+		var kind T
+		if k, ok := interface{}(kind).(IDKind); ok {
+			uuid.SetPreferences(k.Preferences())
+		}
+	}
+	return uuid
+}
+
+// UUID returns the UUID held by this ID.
+func (id ID[T]) UUID() UUID {
+	return id.resolve()
+}
+
+// Preferences returns the preference knobs for this object.
+func (id ID[T]) Preferences() Preferences {
+	return id.resolve().Preferences()
+}
+
+// SetPreferences updates the preference knobs for this object.
+func (id *ID[T]) SetPreferences(pref Preferences) {
+	id.uuid = id.resolve()
+	id.uuid.SetPreferences(pref)
+}
+
+// IsNil returns true iff this object holds the Nil UUID.
+func (id ID[T]) IsNil() bool {
+	return id.uuid.IsNil()
+}
+
+// IsMax returns true iff this object holds the Max UUID.
+func (id ID[T]) IsMax() bool {
+	return id.uuid.IsMax()
+}
+
+// Equal returns true iff this object and the argument hold the same UUID.
+func (id ID[T]) Equal(other ID[T]) bool {
+	return id.uuid.Equal(other.uuid)
+}
+
+// Key returns a comparable Key that ignores this ID's Preferences.
+func (id ID[T]) Key() Key {
+	return id.uuid.Key()
+}
+
+// Bytes returns the binary representation of this ID.
+func (id ID[T]) Bytes() []byte {
+	return id.resolve().Bytes()
+}
+
+// String fulfills the "fmt".Stringer interface.
+func (id ID[T]) String() string {
+	return id.resolve().String()
+}
+
+// GoString fulfills the "fmt".GoStringer interface.
+func (id ID[T]) GoString() string {
+	return id.resolve().GoString()
+}
+
+// Format fulfills the "fmt".Formatter interface.
+func (id ID[T]) Format(s fmt.State, verb rune) {
+	id.resolve().Format(s, verb)
+}
+
+// MarshalBinary fulfills the "encoding".BinaryMarshaler interface.
+func (id ID[T]) MarshalBinary() ([]byte, error) {
+	return id.resolve().MarshalBinary()
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+func (id ID[T]) MarshalText() ([]byte, error) {
+	return id.resolve().MarshalText()
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+func (id ID[T]) MarshalJSON() ([]byte, error) {
+	return id.resolve().MarshalJSON()
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+func (id ID[T]) Value() (driver.Value, error) {
+	return id.resolve().Value()
+}
+
+// SQLLiteral returns a SQL literal in the given Dialect that matches Value.
+func (id ID[T]) SQLLiteral(d Dialect) string {
+	return id.resolve().SQLLiteral(d)
+}
+
+// FromString attempts to parse a textual UUID representation.
+func (id *ID[T]) FromString(in string) error {
+	id.uuid = id.resolve()
+	return id.uuid.unmarshalText("ID", "FromString", []byte(in))
+}
+
+// UnmarshalBinary fulfills the "encoding".BinaryUnmarshaler interface.
+func (id *ID[T]) UnmarshalBinary(in []byte) error {
+	id.uuid = id.resolve()
+	return unmarshalBinary("ID", "UnmarshalBinary", id.uuid.a[:], in, id.uuid.getBits())
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+func (id *ID[T]) UnmarshalText(in []byte) error {
+	id.uuid = id.resolve()
+	return id.uuid.unmarshalText("ID", "UnmarshalText", in)
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+func (id *ID[T]) UnmarshalJSON(in []byte) error {
+	id.uuid = id.resolve()
+	return id.uuid.UnmarshalJSON(in)
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+func (id *ID[T]) Scan(value interface{}) error {
+	id.uuid = id.resolve()
+	return id.uuid.Scan(value)
+}
@@ -0,0 +1,98 @@
+package uuid
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+type testUserKind struct{}
+
+type testOrderKind struct{}
+
+func (testOrderKind) Preferences() Preferences {
+	return Preferences{Value: Binary, Binary: StandardOnly, Text: Canonical}
+}
+
+func TestID_Preferences(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	textDense := "@EeiKtHe5nOqWqBheD61jNQ"
+	standardBytes := []byte{
+		0x77, 0xb9, 0x9c, 0xea,
+		0x8a, 0xb4, 0x11, 0xe8,
+		0x96, 0xa8, 0x18, 0x5e,
+		0x0f, 0xad, 0x63, 0x35,
+	}
+
+	user := MustParseID[testUserKind](text)
+	checkString(t, "user String", textDense, user.String())
+	checkString(t, "user Format %v", textDense, fmt.Sprintf("%v", user))
+	checkText(t, "user MarshalJSON", quoted(textDense), justBytes(user.MarshalJSON()))
+	checkValue(t, "user Value", textDense, justValue(user.Value()))
+
+	order := MustParseID[testOrderKind](text)
+	checkPrefs(t, "order", Binary, StandardOnly, Canonical, order.UUID())
+	checkString(t, "order String", text, order.String())
+	checkString(t, "order Format %v", text, fmt.Sprintf("%v", order))
+	checkText(t, "order MarshalText", text, justBytes(order.MarshalText()))
+	checkValue(t, "order Value", standardBytes, justValue(order.Value()))
+
+	order.SetPreferences(Preferences{Text: Dense})
+	checkPrefs(t, "order after SetPreferences", Binary, StandardOnly, Dense, order.UUID())
+
+	var zero ID[testOrderKind]
+	checkPrefs(t, "zero order", Binary, StandardOnly, Canonical, zero.UUID())
+	if !zero.IsNil() {
+		t.Errorf("zero ID is not Nil")
+	}
+
+	generated := NewID[testOrderKind]()
+	checkGenerated(t, "NewID", generated.UUID().StandardBytes())
+	checkPrefs(t, "NewID", Binary, StandardOnly, Canonical, generated.UUID())
+}
+
+func TestID_Unmarshal(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	standardBytes := []byte{
+		0x77, 0xb9, 0x9c, 0xea,
+		0x8a, 0xb4, 0x11, 0xe8,
+		0x96, 0xa8, 0x18, 0x5e,
+		0x0f, 0xad, 0x63, 0x35,
+	}
+	expect := MustFromString(text)
+
+	type record struct {
+		User  ID[testUserKind]  `json:"user"`
+		Order ID[testOrderKind] `json:"order"`
+	}
+	var r record
+	if err := json.Unmarshal([]byte(`{"user":"@EeiKtHe5nOqWqBheD61jNQ","order":"`+text+`"}`), &r); err != nil {
+		t.Errorf("failed to json.Unmarshal: %v", err)
+		return
+	}
+	checkEqual(t, "user UnmarshalJSON", true, expect, r.User.UUID())
+	checkEqual(t, "order UnmarshalJSON", true, expect, r.Order.UUID())
+
+	raw, err := json.Marshal(r)
+	if err != nil {
+		t.Errorf("failed to json.Marshal: %v", err)
+		return
+	}
+	checkString(t, "json.Marshal", `{"user":"@EeiKtHe5nOqWqBheD61jNQ","order":"`+text+`"}`, string(raw))
+
+	var order ID[testOrderKind]
+	if err := order.Scan(standardBytes); err != nil {
+		t.Errorf("failed to Scan: %v", err)
+	}
+	checkEqual(t, "order Scan", true, expect, order.UUID())
+
+	order = ID[testOrderKind]{}
+	if err := order.UnmarshalBinary(standardBytes); err != nil {
+		t.Errorf("failed to UnmarshalBinary: %v", err)
+	}
+	checkEqual(t, "order UnmarshalBinary", true, expect, order.UUID())
+
+	if _, err := ParseID[testUserKind]("bogus"); err == nil {
+		t.Errorf("unexpected success at ParseID %q", "bogus")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"strings"
 )
 
 var (
@@ -18,6 +19,11 @@ var (
 
 var allZeroes [ByteLength]byte
 
+var allOnes = [ByteLength]byte{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+}
+
 type pair struct{ i, j uint }
 
 var hashlikePairs = []pair{
@@ -129,6 +135,28 @@ func isZero(in []byte) bool {
 	return true
 }
 
+func isAllOnes(in []byte) bool {
+	for _, b := range in {
+		if b != 0xff {
+			return false
+		}
+	}
+	return len(in) != 0
+}
+
+// joinOr joins names as an English list, e.g. "a, b, or c".
+func joinOr(names []string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " or " + names[1]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + ", or " + names[len(names)-1]
+}
+
 func importStandard(out, in []byte) {
 	copy(out, in)
 }
@@ -137,6 +165,43 @@ func exportStandard(out, in []byte) {
 	copy(out, in)
 }
 
+func importGUID(out, in []byte) {
+	out[0] = in[3]
+	out[1] = in[2]
+	out[2] = in[1]
+	out[3] = in[0]
+	out[4] = in[5]
+	out[5] = in[4]
+	out[6] = in[7]
+	out[7] = in[6]
+	copy(out[8:16], in[8:16])
+}
+
+func exportGUID(out, in []byte) {
+	importGUID(out, in)
+}
+
+// sqlServerPositions lists the positions at which SQL Server's
+// uniqueidentifier comparison looks at its bytes, from most significant to
+// least significant.
+var sqlServerPositions = [ByteLength]byte{10, 11, 12, 13, 14, 15, 8, 9, 6, 7, 4, 5, 0, 1, 2, 3}
+
+func importSQLServer(out, in []byte) {
+	var tmp [ByteLength]byte
+	for k, i := range sqlServerPositions {
+		tmp[k] = in[i]
+	}
+	importDense(out, tmp[:])
+}
+
+func exportSQLServer(out, in []byte) {
+	var tmp [ByteLength]byte
+	exportDense(tmp[:], in)
+	for k, i := range sqlServerPositions {
+		out[i] = tmp[k]
+	}
+}
+
 func importDense(out, in []byte) {
 	out[0] = in[4]
 	out[1] = in[5]
@@ -162,7 +227,7 @@ func exportDense(out, in []byte) {
 }
 
 func extract(in []byte) (versionStandard Version, versionDense Version, variant Variant) {
-	if len(in) == ByteLength && !isZero(in) {
+	if len(in) == ByteLength && !isZero(in) && !isAllOnes(in) {
 		versionStandard = Version((in[6] & 0xf0) >> 4)
 		versionDense = Version((in[0] & 0xf0) >> 4)
 		switch (in[8] & 0xe0) >> 5 {
@@ -183,20 +248,17 @@ func extract(in []byte) (versionStandard Version, versionDense Version, variant
 	return
 }
 
-func pickStandard(version, _ Version) Version {
-	return version
-}
-
-func pickDense(_, version Version) Version {
-	return version
-}
-
 func marshalBinary(out, in []byte, x bits) {
-	if x.has(bitBinaryIsDense) {
+	switch {
+	case x.has(binaryModeSQLServer):
+		marshalBinarySQLServer(out, in)
+	case x.has(bitBinaryIsGUID):
+		marshalBinaryGUID(out, in)
+	case x.has(bitBinaryIsDense):
 		marshalBinaryDense(out, in)
-		return
+	default:
+		marshalBinaryStandard(out, in)
 	}
-	marshalBinaryStandard(out, in)
 }
 
 func marshalBinaryStandard(out, in []byte) {
@@ -207,12 +269,23 @@ func marshalBinaryDense(out, in []byte) {
 	exportDense(out, in)
 }
 
+func marshalBinaryGUID(out, in []byte) {
+	exportGUID(out, in)
+}
+
+func marshalBinarySQLServer(out, in []byte) {
+	exportSQLServer(out, in)
+}
+
 func marshalText(w *sliceWriter, in []byte, x bits) {
+	if x.just(bitsNil) == nilModeEmpty && isZero(in) {
+		return
+	}
 	if x.has(bitTextIsDense) {
 		marshalTextDense(w, in)
 		return
 	}
-	switch x.just(bitTextIsModeX | bitTextIsModeY) {
+	switch x.just(bitTextIsUpper | bitTextIsModeX | bitTextIsModeY) {
 	case textModeCanonical:
 		marshalTextCanonical(w, in)
 	case textModeHashLike:
@@ -221,26 +294,32 @@ func marshalText(w *sliceWriter, in []byte, x bits) {
 		marshalTextBracketed(w, in)
 	case textModeURN:
 		marshalTextURN(w, in)
+	case textModeRegistry:
+		marshalTextRegistry(w, in)
 	}
 }
 
 func marshalTextCanonical(w *sliceWriter, in []byte) {
-	marshalTextHelper(w, in, "", "", canonicalPairs)
+	marshalTextHelper(w, in, "", "", canonicalPairs, false)
 }
 
 func marshalTextHashLike(w *sliceWriter, in []byte) {
-	marshalTextHelper(w, in, "", "", hashlikePairs)
+	marshalTextHelper(w, in, "", "", hashlikePairs, false)
 }
 
 func marshalTextBracketed(w *sliceWriter, in []byte) {
-	marshalTextHelper(w, in, "{", "}", canonicalPairs)
+	marshalTextHelper(w, in, "{", "}", canonicalPairs, false)
 }
 
 func marshalTextURN(w *sliceWriter, in []byte) {
-	marshalTextHelper(w, in, "urn:uuid:", "", canonicalPairs)
+	marshalTextHelper(w, in, "urn:uuid:", "", canonicalPairs, false)
 }
 
-func marshalTextHelper(w *sliceWriter, in []byte, pre, post string, pairs []pair) {
+func marshalTextRegistry(w *sliceWriter, in []byte) {
+	marshalTextHelper(w, in, "{", "}", canonicalPairs, true)
+}
+
+func marshalTextHelper(w *sliceWriter, in []byte, pre, post string, pairs []pair, upper bool) {
 	var tmp [ByteLength]byte
 	exportStandard(tmp[:], in)
 
@@ -251,6 +330,13 @@ func marshalTextHelper(w *sliceWriter, in []byte, pre, post string, pairs []pair
 		}
 		slice := w.Grab(2 * (p.j - p.i))
 		hex.Encode(slice, tmp[p.i:p.j])
+		if upper {
+			for k, ch := range slice {
+				if ch >= 'a' && ch <= 'f' {
+					slice[k] = ch - 'a' + 'A'
+				}
+			}
+		}
 	}
 	w.WriteString(post)
 }
@@ -268,7 +354,32 @@ func marshalTextDense(w *sliceWriter, in []byte) {
 	w.Unwrite(2) // trim unnecessary "==" suffix
 }
 
+func marshalJSON(w *sliceWriter, in []byte, x bits) {
+	x = x.forChannel(channelJSON)
+	if isZero(in) {
+		switch x.just(bitsNil) {
+		case nilModeNull, nilModeJSONNull:
+			w.WriteString("null")
+			return
+		}
+	}
+	w.WriteByte('"')
+	marshalText(w, in, x)
+	w.WriteByte('"')
+}
+
 func valueImpl(in []byte, x bits) driver.Value {
+	if isZero(in) {
+		switch x.just(bitsNil) {
+		case nilModeNull:
+			return nil
+		case nilModeEmpty:
+			if x.has(bitValueIsBinary) {
+				return []byte{}
+			}
+			return ""
+		}
+	}
 	if x.has(bitValueIsBinary) {
 		var out [ByteLength]byte
 		marshalBinary(out[:], in, x)
@@ -276,30 +387,35 @@ func valueImpl(in []byte, x bits) driver.Value {
 	}
 	w := makeSliceWriter(bufferLength)
 	defer w.release()
-	marshalText(&w, in, x)
+	marshalText(&w, in, x.forChannel(channelSQL))
 	return w.String()
 }
 
 func unmarshalBinary(typeName, methodName string, out, in []byte, x bits) error {
+	lenient := x.lenient()
 	preferDense := false
 	switch x.just(bitsBinary) {
+	case bitBinaryIsGUID:
+		return unmarshalBinaryGUID(typeName, methodName, out, in, x)
+
+	case binaryModeSQLServer:
+		return unmarshalBinarySQLServer(typeName, methodName, out, in, x)
+
 	case 0:
-		f := pickStandard
 		g := importStandard
-		return unmarshalBinaryHelper(typeName, methodName, out, in, f, g)
+		return unmarshalBinaryHelper(typeName, methodName, out, in, g, lenient, x)
 
 	case bitBinaryIsDense:
-		f := pickDense
 		g := importDense
-		return unmarshalBinaryHelper(typeName, methodName, out, in, f, g)
+		return unmarshalBinaryHelper(typeName, methodName, out, in, g, lenient, x)
 
 	case bitBinaryIsDense | bitBinaryIsLoose:
 		preferDense = true
 	}
 
 	versionStandard, versionDense, _ := extract(in)
-	validStandard := versionStandard.IsValid()
-	validDense := versionDense.IsValid()
+	validStandard := isGuessable(versionStandard)
+	validDense := isGuessable(versionDense)
 
 	// +-----+---------------+------------+-------------+----------------------+
 	// | row | validStandard | validDense | preferDense | outcome              |
@@ -316,16 +432,14 @@ func unmarshalBinary(typeName, methodName string, out, in []byte, x bits) error
 
 	if !validStandard && (validDense || preferDense) {
 		// B, C, D
-		f := pickDense
 		g := importDense
-		return unmarshalBinaryHelper(typeName, methodName, out, in, f, g)
+		return unmarshalBinaryHelper(typeName, methodName, out, in, g, lenient, x)
 	}
 
 	if !validDense {
 		// A, E, F [B already covered]
-		f := pickStandard
 		g := importStandard
-		return unmarshalBinaryHelper(typeName, methodName, out, in, f, g)
+		return unmarshalBinaryHelper(typeName, methodName, out, in, g, lenient, x)
 	}
 
 	// Only G and H remain
@@ -369,26 +483,43 @@ func unmarshalBinary(typeName, methodName string, out, in []byte, x bits) error
 	}
 
 	if probStandard < probDense || (probStandard == probDense && preferDense) {
-		f := pickDense
 		g := importDense
-		return unmarshalBinaryHelper(typeName, methodName, out, in, f, g)
+		return unmarshalBinaryHelper(typeName, methodName, out, in, g, lenient, x)
 	}
 
-	f := pickStandard
 	g := importStandard
-	return unmarshalBinaryHelper(typeName, methodName, out, in, f, g)
+	return unmarshalBinaryHelper(typeName, methodName, out, in, g, lenient, x)
+}
+
+// isGuessable returns true iff version counts as plausible when guessing the
+// byte order of binary input.  Only the RFC 4122 versions count: with V6-V8
+// as well, random V4 input would read as dense far more often.
+func isGuessable(version Version) bool {
+	return version >= V1 && version <= V5
 }
 
-func unmarshalBinaryStandard(typeName, methodName string, out, in []byte) error {
-	f := pickStandard
+func unmarshalBinaryStandard(typeName, methodName string, out, in []byte, x bits) error {
+	lenient := x.lenient()
 	g := importStandard
-	return unmarshalBinaryHelper(typeName, methodName, out, in, f, g)
+	return unmarshalBinaryHelper(typeName, methodName, out, in, g, lenient, x)
 }
 
-func unmarshalBinaryDense(typeName, methodName string, out, in []byte) error {
-	f := pickDense
+func unmarshalBinaryDense(typeName, methodName string, out, in []byte, x bits) error {
+	lenient := x.lenient()
 	g := importDense
-	return unmarshalBinaryHelper(typeName, methodName, out, in, f, g)
+	return unmarshalBinaryHelper(typeName, methodName, out, in, g, lenient, x)
+}
+
+func unmarshalBinaryGUID(typeName, methodName string, out, in []byte, x bits) error {
+	lenient := true
+	g := importGUID
+	return unmarshalBinaryHelper(typeName, methodName, out, in, g, lenient, x)
+}
+
+func unmarshalBinarySQLServer(typeName, methodName string, out, in []byte, x bits) error {
+	lenient := false
+	g := importSQLServer
+	return unmarshalBinaryHelper(typeName, methodName, out, in, g, lenient, x)
 }
 
 func unmarshalBinaryHelper(
@@ -396,41 +527,170 @@ func unmarshalBinaryHelper(
 	methodName string,
 	out []byte,
 	in []byte,
-	f func(_, _ Version) Version,
 	g func(_, _ []byte),
+	lenient bool,
+	x bits,
 ) error {
 	if len(in) == 0 || equalBytes(allZeroes[:], in) {
 		zeroBytes(out)
 		return nil
 	}
-
-	versionStandard, versionDense, variant := extract(in)
-	version := f(versionStandard, versionDense)
-	if !version.IsValid() {
-		return makeParseError(typeName, methodName, in, false).detailf("expected version V1-V5, got %s", version)
+	if equalBytes(allOnes[:], in) {
+		copy(out, allOnes[:])
+		return nil
+	}
+	if len(in) != ByteLength {
+		return lengthError(typeName, methodName, in)
 	}
-	if !variant.IsValid() {
-		return makeParseError(typeName, methodName, in, false).detailf("expected VariantRFC4122, got %s", variant)
+
+	var tmp [ByteLength]byte
+	g(tmp[:], in)
+	if v := validate(tmp[:], x, lenient); v.kind != 0 {
+		return v.apply(makeParseError(typeName, methodName, in, false))
 	}
-	g(out, in)
+	copy(out, tmp[:])
 	return nil
 }
 
-func unmarshalText(typeName, methodName string, out, in []byte) error {
+// lengthError describes binary input which is not ByteLength bytes long.
+func lengthError(typeName, methodName string, in []byte) ParseError {
+	kind, pos := KindTooShort, len(in)
+	if len(in) > ByteLength {
+		kind, pos = KindTrailingData, ByteLength
+	}
+	got := fmt.Sprintf("%d bytes", len(in))
+	expected := fmt.Sprintf("%d bytes", ByteLength)
+	err := makeParseError(typeName, methodName, in, false).at(pos, kind, got, expected)
+	return err.detailf("expected %d bytes, got %d", ByteLength, len(in))
+}
+
+// validate applies the ValidationMode of x to a UUID in standard layout.
+// If lenient is true, VariantMicrosoft is accepted as well as VariantRFC4122.
+func validate(in []byte, x bits, lenient bool) violation {
+	return makePolicy(x, lenient).check(in)
+}
+
+// violation describes why a policy did not accept a value.  The zero
+// violation means that the value was accepted.
+type violation struct {
+	kind     ErrorKind
+	got      string
+	expected string
+	rule     string // for KindRejected, the rule that rejected the value
+}
+
+func (v violation) apply(err ParseError) ParseError {
+	err = err.at(-1, v.kind, v.got, v.expected)
+	if v.kind == KindRejected {
+		return err.detailf("%s is rejected by %s", v.got, v.rule)
+	}
+	return err.detailf("expected %s, got %s", v.expected, v.got)
+}
+
+// policy describes which parsed values are accepted.
+type policy struct {
+	mode      bits       // validationReject or validationAny
+	versions  VersionSet // if non-empty, replaces the version rule of mode
+	variants  []Variant  // if non-empty, replaces the default variant rule
+	lenient   bool
+	rejectNil bool
+	rejectMax bool
+}
+
+func makePolicy(x bits, lenient bool) policy {
+	return policy{
+		mode:    x.just(bitsValidation),
+		lenient: lenient,
+	}
+}
+
+// check applies the policy to a UUID in standard layout.
+func (pol policy) check(in []byte) violation {
+	if isZero(in) {
+		if pol.rejectNil {
+			return violation{kind: KindRejected, got: "Nil UUID", rule: "RejectNil"}
+		}
+		return violation{}
+	}
+	if isAllOnes(in) {
+		if pol.rejectMax {
+			return violation{kind: KindRejected, got: "Max UUID", rule: "RejectMax"}
+		}
+		return violation{}
+	}
+
+	version, _, variant := extract(in)
+	switch {
+	case pol.versions != 0:
+		if !pol.versions.Has(version) {
+			return violation{kind: KindBadVersion, got: version.String(), expected: "version " + pol.versions.String()}
+		}
+	case pol.mode == validationAny:
+		// pass
+	default:
+		if !version.IsValid() {
+			return violation{kind: KindBadVersion, got: version.String(), expected: "version V1-V8"}
+		}
+	}
+
+	if len(pol.variants) != 0 {
+		for _, v := range pol.variants {
+			if v == variant {
+				return violation{}
+			}
+		}
+		names := make([]string, len(pol.variants))
+		for i, v := range pol.variants {
+			names[i] = v.String()
+		}
+		return violation{kind: KindBadVariant, got: variant.String(), expected: joinOr(names)}
+	}
+	if pol.mode == validationAny && pol.versions == 0 {
+		return violation{}
+	}
+	if !variant.IsValid() && !(pol.lenient && variant == VariantMicrosoft) {
+		return violation{kind: KindBadVariant, got: variant.String(), expected: VariantRFC4122.String()}
+	}
+	return violation{}
+}
+
+func unmarshalText(typeName, methodName string, out, in []byte, x bits) error {
+	_, err := detectText(typeName, methodName, out, in, x)
+	return err
+}
+
+// detectText is like unmarshalText, but it also reports the TextMode whose
+// format the input was written in, or 0 for empty input.
+func detectText(typeName, methodName string, out, in []byte, x bits) (TextMode, error) {
+	var tmp [ByteLength]byte
+	tm, err := detectTextHelper(typeName, methodName, tmp[:], in)
+	if err != nil {
+		return 0, err
+	}
+	if v := validate(tmp[:], x, x.lenient()); v.kind != 0 {
+		return 0, v.apply(makeParseError(typeName, methodName, in, true))
+	}
+	copy(out, tmp[:])
+	return tm, nil
+}
+
+func detectTextHelper(typeName, methodName string, out, in []byte) (TextMode, error) {
 	r := makeSliceReader(in)
 	if r.IsEOF() {
 		zeroBytes(out)
-		return nil
+		return 0, nil
 	}
 	r.TrimLeading(isSpace)
 	if r.HasPrefix(atSign) {
 		r.TrimPrefix(uint(len(atSign)))
 		r.TrimLeading(isSpace)
-		return unmarshalTextDense(typeName, methodName, out, &r)
+		return Dense, unmarshalTextDense(typeName, methodName, out, &r)
 	}
+	isURN := false
 	if r.HasPrefix(urnPrefix) {
 		r.TrimPrefix(uint(len(urnPrefix)))
 		r.TrimLeading(isSpace)
+		isURN = true
 	}
 	needClose := false
 	if r.HasPrefix(openBracket) {
@@ -438,7 +698,43 @@ func unmarshalText(typeName, methodName string, out, in []byte) error {
 		r.TrimLeading(isSpace)
 		needClose = true
 	}
-	return unmarshalTextStandard(typeName, methodName, out, &r, needClose)
+	digits := in[r.CurrentOffset():]
+	if err := unmarshalTextStandard(typeName, methodName, out, &r, needClose); err != nil {
+		return 0, err
+	}
+
+	hasHyphen, hasUpper, hasLower := false, false, false
+	for _, ch := range digits {
+		switch {
+		case ch == '-':
+			hasHyphen = true
+		case ch >= 'A' && ch <= 'F':
+			hasUpper = true
+		case ch >= 'a' && ch <= 'f':
+			hasLower = true
+		}
+	}
+	switch {
+	case isURN:
+		return URN, nil
+	case needClose && hasUpper && !hasLower:
+		return Registry, nil
+	case needClose:
+		return Bracketed, nil
+	case hasHyphen:
+		return Canonical, nil
+	default:
+		return HashLike, nil
+	}
+}
+
+// echoBits returns x with its Text mode set to tm if x has Echo turned on.
+// The boolean result reports whether x was changed.
+func echoBits(x bits, tm TextMode) (bits, bool) {
+	if tm == 0 || !x.has(bitTextEcho) {
+		return x, false
+	}
+	return Preferences{Text: tm}.collapse(x), true
 }
 
 func unmarshalTextStandard(typeName, methodName string, out []byte, r *sliceReader, needClose bool) error {
@@ -492,30 +788,36 @@ func unmarshalTextStandard(typeName, methodName string, out []byte, r *sliceRead
 			continue
 		}
 
+		kind := KindBadByte
 		var expected string
 		if allowAny {
 			expected = "0-9, A-F, a-f, or -"
 		} else if allowBracket {
 			expected = "}"
 		} else {
+			kind = KindTrailingData
 			expected = "end of input"
 		}
 		i := r.CurrentOffset() - 1
 		in := r.slice[0:r.j]
-		return makeParseError(typeName, methodName, in, true).detailf("unexpected byte %q %#02x at position %d, expected %s", ch, ch, i, expected)
+		err := makeParseError(typeName, methodName, in, true).at(int(i), kind, fmt.Sprintf("%q", ch), expected)
+		return err.detailf("unexpected byte %q %#02x at position %d, expected %s", ch, ch, i, expected)
 	}
 
 	if w.Remain() > 0 {
 		i := r.CurrentOffset()
 		in := r.slice[0:r.j]
 		more := w.Remain()*2 - partialCount
-		return makeParseError(typeName, methodName, in, true).detailf("unexpected end of input at position %d, expected %d more hex digits", i, more)
+		expected := fmt.Sprintf("%d more hex digits", more)
+		err := makeParseError(typeName, methodName, in, true).at(int(i), KindTooShort, "end of input", expected)
+		return err.detailf("unexpected end of input at position %d, expected %s", i, expected)
 	}
 
 	if allowBracket {
 		i := r.CurrentOffset()
 		in := r.slice[0:r.j]
-		return makeParseError(typeName, methodName, in, true).detailf("unexpected end of input at position %d, expected '}'", i)
+		err := makeParseError(typeName, methodName, in, true).at(int(i), KindTooShort, "end of input", "'}'")
+		return err.detailf("unexpected end of input at position %d, expected '}'", i)
 	}
 
 	importStandard(out, w.Bytes())
@@ -545,6 +847,7 @@ func unmarshalTextDense(typeName, methodName string, out []byte, r *sliceReader)
 
 	allowAny := true
 	allowEqual := true
+	lastDigit := r.CurrentOffset()
 	for !r.IsEOF() {
 		if w.Remain() <= 0 {
 			allowAny = false
@@ -552,6 +855,9 @@ func unmarshalTextDense(typeName, methodName string, out []byte, r *sliceReader)
 		}
 
 		ch := r.ReadByte()
+		if ch != '=' && !isSpace(ch) {
+			lastDigit = r.CurrentOffset() - 1
+		}
 		if allowAny && ch >= 'A' && ch <= 'Z' {
 			absorb(ch - 'A' + 0x0000)
 			continue
@@ -585,17 +891,20 @@ func unmarshalTextDense(typeName, methodName string, out []byte, r *sliceReader)
 			continue
 		}
 
+		kind := KindBadByte
 		var expected string
 		if allowAny {
 			expected = "A-Z, a-z, 0-9, +, /, -, _, or ="
 		} else if allowEqual {
 			expected = "="
 		} else {
+			kind = KindTrailingData
 			expected = "end of input"
 		}
 		i := r.CurrentOffset() - 1
 		in := r.slice[0:r.j]
-		return makeParseError(typeName, methodName, in, true).detailf("unexpected byte %q %#02x at position %d, expected %s", ch, ch, i, expected)
+		err := makeParseError(typeName, methodName, in, true).at(int(i), kind, fmt.Sprintf("%q", ch), expected)
+		return err.detailf("unexpected byte %q %#02x at position %d, expected %s", ch, ch, i, expected)
 	}
 
 	// Allow incomplete base-64 sequences
@@ -607,7 +916,9 @@ func unmarshalTextDense(typeName, methodName string, out []byte, r *sliceReader)
 		i := r.CurrentOffset()
 		in := r.slice[0:r.j]
 		more := (w.Remain()/3)*4 - partialCount
-		return makeParseError(typeName, methodName, in, true).detailf("unexpected end of input at position %d, expected %d more base-64 digits", i, more)
+		expected := fmt.Sprintf("%d more base-64 digits", more)
+		err := makeParseError(typeName, methodName, in, true).at(int(i), KindTooShort, "end of input", expected)
+		return err.detailf("unexpected end of input at position %d, expected %s", i, expected)
 	}
 
 	slice := w.Bytes()
@@ -617,22 +928,61 @@ func unmarshalTextDense(typeName, methodName string, out []byte, r *sliceReader)
 		slice[17] = 0
 		expect := base64.StdEncoding.EncodeToString(slice[15:18])
 		in := r.slice[0:r.j]
-		return makeParseError(typeName, methodName, in, true).detailf("unexpected data at end of input, expected %q but got %q", expect, got)
+		err := makeParseError(typeName, methodName, in, true).at(int(lastDigit), KindTrailingData, got, expect)
+		return err.detailf("unexpected data at end of input, expected %q but got %q", expect, got)
 	}
 
 	importDense(out, slice[0:ByteLength])
 	return nil
 }
 
-func scanImpl(typeName, methodName string, out, in []byte, isDefinitelyText bool, x bits) error {
+func scanValue(typeName, methodName string, out []byte, value interface{}, x bits) (TextMode, error) {
+	switch v := value.(type) {
+	case nil:
+		zeroBytes(out)
+		return 0, nil
+	case []byte:
+		return scanImpl(typeName, methodName, out, v, false, x)
+	case string:
+		// Some drivers deliver BINARY(16) columns as strings.
+		in := []byte(v)
+		isDefinitelyText := len(in) != ByteLength || isText(in)
+		return scanImpl(typeName, methodName, out, in, isDefinitelyText, x)
+	case [ByteLength]byte:
+		return scanImpl(typeName, methodName, out, v[:], false, x)
+	case UUID:
+		return 0, scanUUID(typeName, methodName, out, v.a[:], x)
+	case *UUID:
+		if v == nil {
+			zeroBytes(out)
+			return 0, nil
+		}
+		return 0, scanUUID(typeName, methodName, out, v.a[:], x)
+	case fmt.Stringer:
+		return scanImpl(typeName, methodName, out, []byte(v.String()), true, x)
+	}
+	return 0, makeTypeError(typeName, methodName, value, nil, []byte(nil), "", [ByteLength]byte{}, UUID{}, (*UUID)(nil))
+}
+
+// scanUUID copies the standard layout of a UUID value, applying the same
+// ValidationMode as any other input.
+func scanUUID(typeName, methodName string, out, in []byte, x bits) error {
+	if v := validate(in, x, x.lenient()); v.kind != 0 {
+		return v.apply(makeParseError(typeName, methodName, in, false))
+	}
+	copy(out, in)
+	return nil
+}
+
+func scanImpl(typeName, methodName string, out, in []byte, isDefinitelyText bool, x bits) (TextMode, error) {
 	if len(in) == 0 {
 		zeroBytes(out)
-		return nil
+		return 0, nil
 	}
 	if isDefinitelyText || (len(in) != ByteLength && isText(in)) {
-		return unmarshalText(typeName, methodName, out, in)
+		return detectText(typeName, methodName, out, in, x)
 	}
-	return unmarshalBinary(typeName, methodName, out, in, x)
+	return 0, unmarshalBinary(typeName, methodName, out, in, x)
 }
 
 func tickProbability(value, now uint64) float64 {
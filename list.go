@@ -0,0 +1,178 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"sort"
+)
+
+// List holds a sequence of UUIDs in one contiguous byte slice, 16 bytes per
+// UUID, with a single set of Preferences shared by the whole List.
+//
+// Compared to a []UUID, a List needs no per-element preference byte and
+// keeps every element aligned on a 16-byte boundary.
+type List struct {
+	data []byte
+	b    bits
+}
+
+var _ driver.Valuer = List{}
+var _ driver.Valuer = (*List)(nil)
+var _ sql.Scanner = (*List)(nil)
+
+// MakeList returns an empty List with room for n UUIDs.
+func MakeList(n int) List {
+	return List{data: make([]byte, 0, n*ByteLength)}
+}
+
+// ListOf returns a List holding the given UUIDs.
+func ListOf(uuids ...UUID) List {
+	list := MakeList(len(uuids))
+	list.Append(uuids...)
+	return list
+}
+
+func (list List) getBits() bits {
+	return list.b.defaulted()
+}
+
+// Preferences returns the preference knobs shared by every UUID in this List.
+func (list List) Preferences() Preferences {
+	return list.b.defaulted().expand()
+}
+
+// SetPreferences updates the preference knobs shared by every UUID in this List.
+func (list *List) SetPreferences(pref Preferences) {
+	list.b = pref.collapse(list.getBits())
+}
+
+// Len returns the number of UUIDs in this List.
+func (list List) Len() int {
+	return len(list.data) / ByteLength
+}
+
+// At returns the i'th UUID in this List, carrying the List's Preferences.
+func (list List) At(i int) UUID {
+	var uuid UUID
+	copy(uuid.a[:], list.record(i))
+	uuid.b = list.getBits().pack()
+	return uuid
+}
+
+// Set replaces the i'th UUID in this List.
+func (list List) Set(i int, uuid UUID) {
+	copy(list.record(i), uuid.a[:])
+}
+
+// Append adds UUIDs to the end of this List.
+func (list *List) Append(uuids ...UUID) {
+	for _, uuid := range uuids {
+		list.data = append(list.data, uuid.a[:]...)
+	}
+}
+
+// UUIDs returns a copy of this List's contents as a slice of UUIDs.
+func (list List) UUIDs() []UUID {
+	out := make([]UUID, list.Len())
+	for i := range out {
+		out[i] = list.At(i)
+	}
+	return out
+}
+
+// Sort sorts this List in the given Order.
+func (list List) Sort(order Order) {
+	order.indices() // panic early on unknown Order
+	sort.Sort(listSorter{list, order})
+}
+
+// IsSorted returns true iff this List is sorted in the given Order.
+func (list List) IsSorted(order Order) bool {
+	order.indices() // panic early on unknown Order
+	return sort.IsSorted(listSorter{list, order})
+}
+
+// Search performs a binary search for uuid in a List that is sorted in the
+// given Order.  It returns the index at which uuid was found, or the index
+// at which it would be inserted along with false.
+func (list List) Search(uuid UUID, order Order) (int, bool) {
+	n := list.Len()
+	i := sort.Search(n, func(i int) bool {
+		return order.compare(list.record(i), uuid.a[:]) >= 0
+	})
+	found := (i < n && order.compare(list.record(i), uuid.a[:]) == 0)
+	return i, found
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+// It produces a PostgreSQL array literal, e.g. `{a,b,c}`, holding the
+// textual representation of each UUID.
+func (list List) Value() (driver.Value, error) {
+	x := list.getBits().forChannel(channelSQL)
+	value := marshalPGArray(list.Len(), func(w *sliceWriter, i int) bool {
+		marshalText(w, list.record(i), x)
+		return true
+	})
+	return value, nil
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+// It attempts to interpret a PostgreSQL array literal as a List of UUIDs.
+func (list *List) Scan(value interface{}) error {
+	var in []byte
+	switch v := value.(type) {
+	case nil:
+		list.data = list.data[:0]
+		return nil
+	case []byte:
+		in = v
+	case string:
+		in = []byte(v)
+	default:
+		return makeTypeError("List", "Scan", value, nil, []byte(nil), "")
+	}
+
+	items, err := parsePGArray("List", "Scan", in)
+	if err != nil {
+		return err
+	}
+	data := make([]byte, len(items)*ByteLength)
+	for i, item := range items {
+		if item == nil {
+			err := makeParseError("List", "Scan", in, true).at(-1, KindRejected, "NULL", "UUID")
+			err.Element = i
+			return err.detailf("element %d: unexpected NULL", i)
+		}
+		out := data[i*ByteLength : (i+1)*ByteLength]
+		if err := unmarshalText("List", "Scan", out, item, list.getBits()); err != nil {
+			return makeParseError("List", "Scan", in, true).element(i, err)
+		}
+	}
+	list.data = data
+	return nil
+}
+
+func (list List) record(i int) []byte {
+	return list.data[i*ByteLength : (i+1)*ByteLength : (i+1)*ByteLength]
+}
+
+type listSorter struct {
+	list  List
+	order Order
+}
+
+func (s listSorter) Len() int {
+	return s.list.Len()
+}
+
+func (s listSorter) Less(i, j int) bool {
+	return s.order.compare(s.list.record(i), s.list.record(j)) < 0
+}
+
+func (s listSorter) Swap(i, j int) {
+	var tmp [ByteLength]byte
+	p, q := s.list.record(i), s.list.record(j)
+	copy(tmp[:], p)
+	copy(p, q)
+	copy(q, tmp[:])
+}
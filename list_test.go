@@ -0,0 +1,123 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	u0 := MustFromString("11d3c015-c015-11d3-96a8-185e0fad6335")
+	u1 := MustFromString("c01511d3-c015-11d2-96a8-185e0fad6335")
+	u2 := MustFromString("77b99cea-8ab4-11e8-96a8-185e0fad6335")
+
+	list := ListOf(u2, u0)
+	list.Append(u1)
+	if n := list.Len(); n != 3 {
+		t.Errorf("wrong Len: expected 3, got %d", n)
+	}
+	checkEqual(t, "At(0)", true, u2, list.At(0))
+	checkEqual(t, "At(1)", true, u0, list.At(1))
+	checkEqual(t, "At(2)", true, u1, list.At(2))
+
+	list.Sort(StandardOrder)
+	checkList(t, "Sort(StandardOrder)", list, u0, u2, u1)
+	if !list.IsSorted(StandardOrder) {
+		t.Errorf("expected IsSorted(StandardOrder) after Sort")
+	}
+	checkSearch(t, "StandardOrder", list, StandardOrder, u2, 1, true)
+	checkSearch(t, "StandardOrder", list, StandardOrder, MustFromString("80000000-0000-1000-8000-000000000000"), 2, false)
+
+	list.Sort(DenseOrder)
+	checkList(t, "Sort(DenseOrder)", list, u1, u0, u2)
+	if !list.IsSorted(DenseOrder) {
+		t.Errorf("expected IsSorted(DenseOrder) after Sort")
+	}
+	checkSearch(t, "DenseOrder", list, DenseOrder, u1, 0, true)
+	checkSearch(t, "DenseOrder", list, DenseOrder, u2, 2, true)
+	checkSearch(t, "DenseOrder", list, DenseOrder, MustFromString("00000000-0000-1fff-8000-000000000000"), 3, false)
+
+	list.Set(0, u2)
+	checkList(t, "Set(0)", list, u2, u0, u2)
+
+	list.SetPreferences(Preferences{Text: Canonical})
+	checkPrefs(t, "At(0) after SetPreferences", Text, DenseFirst, Canonical, list.At(0))
+	if uuids := list.UUIDs(); len(uuids) != 3 || !uuids[1].Equal(u0) {
+		t.Errorf("flubbed UUIDs: got %v", uuids)
+	}
+}
+
+func TestList_SQL(t *testing.T) {
+	u0 := MustFromString("11d3c015-c015-11d3-96a8-185e0fad6335")
+	u1 := MustFromString("77b99cea-8ab4-11e8-96a8-185e0fad6335")
+
+	type testrow struct {
+		tm     TextMode
+		output string
+	}
+	data := []testrow{
+		{Canonical, `{11d3c015-c015-11d3-96a8-185e0fad6335,77b99cea-8ab4-11e8-96a8-185e0fad6335}`},
+		{Dense, `{@EdPAFRHTwBWWqBheD61jNQ,@EeiKtHe5nOqWqBheD61jNQ}`},
+		{Bracketed, `{"{11d3c015-c015-11d3-96a8-185e0fad6335}","{77b99cea-8ab4-11e8-96a8-185e0fad6335}"}`},
+	}
+	for _, row := range data {
+		t.Run(row.tm.String(), func(t *testing.T) {
+			list := ListOf(u0, u1)
+			list.SetPreferences(Preferences{Text: row.tm})
+			checkValue(t, "Value", row.output, justValue(list.Value()))
+
+			var parsed List
+			if err := parsed.Scan(row.output); err != nil {
+				t.Errorf("failed to Scan %q: %v", row.output, err)
+				return
+			}
+			checkList(t, "Scan", parsed, u0, u1)
+
+			parsed = List{}
+			if err := parsed.Scan([]byte(row.output)); err != nil {
+				t.Errorf("failed to Scan %q: %v", row.output, err)
+				return
+			}
+			checkList(t, "Scan", parsed, u0, u1)
+		})
+	}
+
+	var list List
+	checkValue(t, "Value", `{}`, justValue(list.Value()))
+	list.Append(u0)
+	if err := list.Scan(nil); err != nil || list.Len() != 0 {
+		t.Errorf("flubbed Scan(nil): %d items, %v", list.Len(), err)
+	}
+
+	faildata := []interface{}{
+		`{77b99cea-8ab4-11e8-96a8-185e0fad6335,NULL}`,
+		`{77b99cea-8ab4-11e8-96a8-185e0fad633}`,
+		`77b99cea-8ab4-11e8-96a8-185e0fad6335`,
+		42,
+	}
+	for _, input := range faildata {
+		if err := list.Scan(input); err == nil {
+			t.Errorf("unexpected success at Scan %v", input)
+		}
+	}
+	err := list.Scan(faildata[0])
+	if pe, ok := err.(ParseError); !ok || !errors.Is(err, ErrRejected) || pe.Element != 1 {
+		t.Errorf("wrong error for NULL element: %#v", err)
+	}
+}
+
+func checkList(t *testing.T, opName string, list List, expect ...UUID) {
+	if list.Len() != len(expect) {
+		t.Errorf("flubbed %s: expected %d items, got %d", opName, len(expect), list.Len())
+		return
+	}
+	for i, u := range expect {
+		checkEqual(t, opName, true, u, list.At(i))
+	}
+}
+
+func checkSearch(t *testing.T, context string, list List, order Order, u UUID, expectIndex int, expectFound bool) {
+	i, found := list.Search(u, order)
+	if i != expectIndex || found != expectFound {
+		t.Errorf("%s: flubbed Search %s: expected (%d, %v), got (%d, %v)", context, u.CanonicalString(), expectIndex, expectFound, i, found)
+	}
+}
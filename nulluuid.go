@@ -0,0 +1,200 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+)
+
+// NullUUID holds a UUID that may be NULL, in the style of "database/sql".NullString.
+//
+// Valid is true iff the UUID is not NULL.  The Preferences of the embedded
+// UUID are respected when the NullUUID is not NULL.
+type NullUUID struct {
+	UUID
+	Valid bool
+}
+
+var _ encoding.BinaryMarshaler = NullUUID{}
+var _ encoding.BinaryMarshaler = (*NullUUID)(nil)
+var _ encoding.BinaryUnmarshaler = (*NullUUID)(nil)
+
+var _ encoding.TextMarshaler = NullUUID{}
+var _ encoding.TextMarshaler = (*NullUUID)(nil)
+var _ encoding.TextUnmarshaler = (*NullUUID)(nil)
+
+var _ json.Marshaler = NullUUID{}
+var _ json.Marshaler = (*NullUUID)(nil)
+var _ json.Unmarshaler = (*NullUUID)(nil)
+
+var _ driver.Valuer = NullUUID{}
+var _ driver.Valuer = (*NullUUID)(nil)
+var _ sql.Scanner = (*NullUUID)(nil)
+
+// MarshalBinary fulfills the "encoding".BinaryMarshaler interface.
+// It produces no bytes if NULL, or a binary representation of the UUID otherwise.
+func (nu NullUUID) MarshalBinary() ([]byte, error) {
+	if !nu.Valid {
+		return []byte{}, nil
+	}
+	return nu.UUID.MarshalBinary()
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+// It produces an empty string if NULL, or a textual representation of the UUID otherwise.
+func (nu NullUUID) MarshalText() ([]byte, error) {
+	if !nu.Valid {
+		return []byte{}, nil
+	}
+	return nu.UUID.MarshalText()
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+// It produces JSON null if NULL, or a textual representation of the UUID as a JSON string otherwise.
+func (nu NullUUID) MarshalJSON() ([]byte, error) {
+	if !nu.Valid {
+		return []byte(`null`), nil
+	}
+	return nu.UUID.MarshalJSON()
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+// It produces SQL NULL if NULL, or a SQL representation of the UUID otherwise.
+func (nu NullUUID) Value() (driver.Value, error) {
+	if !nu.Valid {
+		return nil, nil
+	}
+	return nu.UUID.Value()
+}
+
+// SQLLiteral returns `NULL` if NULL, or a SQL literal in the given Dialect
+// that matches Value otherwise.
+func (nu NullUUID) SQLLiteral(d Dialect) string {
+	if !nu.Valid {
+		return sqlLiteral(nil, 0, d)
+	}
+	return nu.UUID.SQLLiteral(d)
+}
+
+// FromStandardBytes attempts to parse a binary UUID representation in RFC 4122 byte order.
+// It treats empty input as NULL.
+func (nu *NullUUID) FromStandardBytes(in []byte) error {
+	return nu.unmarshalBinary("FromStandardBytes", in, unmarshalBinaryStandard)
+}
+
+// FromGUIDBytes attempts to parse a binary UUID representation in Microsoft GUID byte order.
+// It treats empty input as NULL.
+func (nu *NullUUID) FromGUIDBytes(in []byte) error {
+	return nu.unmarshalBinary("FromGUIDBytes", in, unmarshalBinaryGUID)
+}
+
+// FromDenseBytes attempts to parse a binary UUID representation in "dense" byte order.
+// It treats empty input as NULL.
+func (nu *NullUUID) FromDenseBytes(in []byte) error {
+	return nu.unmarshalBinary("FromDenseBytes", in, unmarshalBinaryDense)
+}
+
+// FromBytes attempts to parse a binary UUID representation.
+// It treats empty input as NULL.
+func (nu *NullUUID) FromBytes(in []byte) error {
+	return nu.unmarshalBinary("FromBytes", in, unmarshalBinary)
+}
+
+// MustFromBytes parses a binary UUID representation, or panics if it cannot.
+// It treats empty input as NULL.
+func (nu *NullUUID) MustFromBytes(in []byte) {
+	if err := nu.unmarshalBinary("MustFromBytes", in, unmarshalBinary); err != nil {
+		panic(err)
+	}
+}
+
+// UnmarshalBinary fulfills the "encoding".BinaryUnmarshaler interface.
+// It treats empty input as NULL, matching MarshalBinary, and attempts to
+// parse any other input as a binary UUID representation.
+func (nu *NullUUID) UnmarshalBinary(in []byte) error {
+	return nu.unmarshalBinary("UnmarshalBinary", in, unmarshalBinary)
+}
+
+// FromString attempts to parse a textual UUID representation.
+// It treats the empty string as NULL.
+func (nu *NullUUID) FromString(in string) error {
+	return nu.unmarshalText("FromString", []byte(in))
+}
+
+// MustFromString parses a textual UUID representation, or panics if it cannot.
+// It treats the empty string as NULL.
+func (nu *NullUUID) MustFromString(in string) {
+	if err := nu.unmarshalText("MustFromString", []byte(in)); err != nil {
+		panic(err)
+	}
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+// It treats empty text as NULL, matching MarshalText, and attempts to parse
+// any other text as a textual UUID representation.
+func (nu *NullUUID) UnmarshalText(in []byte) error {
+	return nu.unmarshalText("UnmarshalText", in)
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+// It treats JSON null as NULL, and attempts to parse any other JSON value
+// as a textual UUID representation.
+func (nu *NullUUID) UnmarshalJSON(in []byte) error {
+	var ptr *string
+	if err := json.Unmarshal(in, &ptr); err != nil {
+		return makeParseError("NullUUID", "UnmarshalJSON", in, true).at(-1, KindBadJSON, "", "").wrap(err).detailf("json.Unmarshal: %v", err)
+	}
+	nu.Valid = false
+	if ptr == nil {
+		zeroBytes(nu.UUID.a[:])
+		return nil
+	}
+	if err := nu.UUID.unmarshalText("NullUUID", "UnmarshalJSON", []byte(*ptr)); err != nil {
+		return err
+	}
+	nu.Valid = true
+	return nil
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+// It treats SQL NULL as NULL, and attempts to interpret any other SQL value
+// as a UUID representation of some kind.
+func (nu *NullUUID) Scan(value interface{}) error {
+	nu.Valid = false
+	if ptr, ok := value.(*UUID); value == nil || (ok && ptr == nil) {
+		zeroBytes(nu.UUID.a[:])
+		return nil
+	}
+	if err := nu.UUID.Scan(value); err != nil {
+		return err
+	}
+	nu.Valid = true
+	return nil
+}
+
+func (nu *NullUUID) unmarshalBinary(methodName string, in []byte, f func(_, _ string, _, _ []byte, _ bits) error) error {
+	nu.Valid = false
+	if len(in) == 0 {
+		zeroBytes(nu.UUID.a[:])
+		return nil
+	}
+	if err := f("NullUUID", methodName, nu.UUID.a[:], in, nu.UUID.getBits()); err != nil {
+		return err
+	}
+	nu.Valid = true
+	return nil
+}
+
+func (nu *NullUUID) unmarshalText(methodName string, in []byte) error {
+	nu.Valid = false
+	if len(in) == 0 {
+		zeroBytes(nu.UUID.a[:])
+		return nil
+	}
+	if err := nu.UUID.unmarshalText("NullUUID", methodName, in); err != nil {
+		return err
+	}
+	nu.Valid = true
+	return nil
+}
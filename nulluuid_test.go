@@ -0,0 +1,134 @@
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullUUID(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	standardBytes := []byte{
+		0x77, 0xb9, 0x9c, 0xea,
+		0x8a, 0xb4, 0x11, 0xe8,
+		0x96, 0xa8, 0x18, 0x5e,
+		0x0f, 0xad, 0x63, 0x35,
+	}
+
+	var nu NullUUID
+	if v := justValue(nu.Value()); v != nil {
+		t.Errorf("flubbed Value of NULL: expected nil, got %#v", v)
+	}
+	checkText(t, "MarshalJSON of NULL", `null`, justBytes(nu.MarshalJSON()))
+	checkText(t, "MarshalText of NULL", ``, justBytes(nu.MarshalText()))
+
+	nu = NullUUID{Valid: true}
+	checkValue(t, "Value of Nil", "@AAAAAAAAAAAAAAAAAAAAAA", justValue(nu.Value()))
+	checkText(t, "MarshalJSON of Nil", `"@AAAAAAAAAAAAAAAAAAAAAA"`, justBytes(nu.MarshalJSON()))
+
+	nu = NullUUID{UUID: MustFromString(text), Valid: true}
+	nu.SetPreferences(Preferences{Value: Binary, Binary: StandardOnly, Text: Canonical})
+	checkValue(t, "Value", standardBytes, justValue(nu.Value()))
+	checkText(t, "MarshalJSON", quoted(text), justBytes(nu.MarshalJSON()))
+	checkText(t, "MarshalText", text, justBytes(nu.MarshalText()))
+
+	type testrow struct {
+		name   string
+		input  interface{}
+		valid  bool
+		output []byte
+	}
+	data := []testrow{
+		{"nil", nil, false, allZeroes[:]},
+		{"bytes-zero", allZeroes[:], true, allZeroes[:]},
+		{"bytes-std", standardBytes, true, standardBytes},
+		{"string-empty", "", true, allZeroes[:]},
+		{"string-text", text, true, standardBytes},
+		{"uuid-ptr-nil", (*UUID)(nil), false, allZeroes[:]},
+	}
+	for _, row := range data {
+		t.Run(row.name, func(t *testing.T) {
+			nu := NullUUID{UUID: New(), Valid: !row.valid}
+			if err := nu.Scan(row.input); err != nil {
+				t.Errorf("failed to Scan %[1]T %[1]v: %v", row.input, err)
+				return
+			}
+			if nu.Valid != row.valid {
+				t.Errorf("wrong Valid after Scan: expected %v, got %v", row.valid, nu.Valid)
+			}
+			checkBinary(t, "Scan", row.output, nu.StandardBytes())
+		})
+	}
+
+	jsondata := []testrow{
+		{"null", `null`, false, allZeroes[:]},
+		{"string-empty", `""`, true, allZeroes[:]},
+		{"string-text", quoted(text), true, standardBytes},
+	}
+	for _, row := range jsondata {
+		t.Run("json-"+row.name, func(t *testing.T) {
+			nu := NullUUID{UUID: New(), Valid: !row.valid}
+			if err := json.Unmarshal([]byte(row.input.(string)), &nu); err != nil {
+				t.Errorf("failed to UnmarshalJSON %s: %v", row.input, err)
+				return
+			}
+			if nu.Valid != row.valid {
+				t.Errorf("wrong Valid after UnmarshalJSON: expected %v, got %v", row.valid, nu.Valid)
+			}
+			checkBinary(t, "UnmarshalJSON", row.output, nu.StandardBytes())
+		})
+	}
+
+	nu = NullUUID{}
+	if err := nu.UnmarshalText([]byte(text)); err != nil || !nu.Valid {
+		t.Errorf("flubbed UnmarshalText: valid=%v, %v", nu.Valid, err)
+	}
+	if err := nu.Scan(42); err == nil || nu.Valid {
+		t.Errorf("unexpected success at Scan of int: valid=%v", nu.Valid)
+	}
+}
+
+func TestNullUUID_RoundTrip(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	values := []NullUUID{
+		{},
+		{Valid: true},
+		{UUID: MustFromString(text), Valid: true},
+	}
+	for _, nu := range values {
+		var parsed NullUUID
+		parsed.Valid = !nu.Valid
+		if err := parsed.UnmarshalText(justBytes(nu.MarshalText())); err != nil {
+			t.Errorf("failed to UnmarshalText %v: %v", nu, err)
+		} else if parsed.Valid != nu.Valid || parsed.UUID != nu.UUID {
+			t.Errorf("wrong text round trip: expected %+v, got %+v", nu, parsed)
+		}
+
+		parsed = NullUUID{UUID: New(), Valid: !nu.Valid}
+		if err := parsed.UnmarshalBinary(justBytes(nu.MarshalBinary())); err != nil {
+			t.Errorf("failed to UnmarshalBinary %v: %v", nu, err)
+		} else if parsed.Valid != nu.Valid || parsed.UUID != nu.UUID {
+			t.Errorf("wrong binary round trip: expected %+v, got %+v", nu, parsed)
+		}
+	}
+
+	if b := justBytes(NullUUID{}.MarshalBinary()); len(b) != 0 {
+		t.Errorf("wrong MarshalBinary of NULL: expected no bytes, got %x", b)
+	}
+
+	var nu NullUUID
+	parsers := map[string]func() error{
+		"FromString":        func() error { return nu.FromString(text) },
+		"FromBytes":         func() error { return nu.FromBytes(MustFromString(text).StandardBytes()) },
+		"FromStandardBytes": func() error { return nu.FromStandardBytes(MustFromString(text).StandardBytes()) },
+		"FromDenseBytes":    func() error { return nu.FromDenseBytes(MustFromString(text).DenseBytes()) },
+	}
+	for name, parse := range parsers {
+		nu = NullUUID{}
+		if err := parse(); err != nil || !nu.Valid || nu.UUID != MustFromString(text) {
+			t.Errorf("flubbed %s: %+v, %v", name, nu, err)
+		}
+	}
+	if err := nu.FromString(""); err != nil || nu.Valid {
+		t.Errorf("flubbed FromString of empty string: valid=%v, %v", nu.Valid, err)
+	}
+}
@@ -0,0 +1,73 @@
+package uuid
+
+import "fmt"
+
+// Order selects a byte ordering under which UUIDs are compared.
+type Order byte
+
+// Order enum constants.
+const (
+	_ Order = iota
+
+	// StandardOrder: compare the RFC 4122 bytes.
+	StandardOrder
+
+	// DenseOrder: compare the "dense" bytes, i.e. V1 UUIDs in chronological order.
+	DenseOrder
+
+	// SQLServerOrder: compare the way SQL Server compares a uniqueidentifier
+	// holding the UUID, i.e. bytes 10-15 first and the byte-swapped time_low
+	// last.  UUIDs stored with SQLServerOnly sort in DenseOrder instead.
+	SQLServerOrder
+)
+
+var orderMap = map[Order]string{
+	StandardOrder:  "StandardOrder",
+	DenseOrder:     "DenseOrder",
+	SQLServerOrder: "SQLServerOrder",
+}
+
+// orderIndices lists, for each Order, the positions of the RFC 4122 bytes
+// from most significant to least significant.
+var orderIndices = map[Order]*[ByteLength]byte{
+	StandardOrder:  {0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	DenseOrder:     {6, 7, 4, 5, 0, 1, 2, 3, 8, 9, 10, 11, 12, 13, 14, 15},
+	SQLServerOrder: {10, 11, 12, 13, 14, 15, 8, 9, 7, 6, 5, 4, 3, 2, 1, 0},
+}
+
+func (order Order) String() string {
+	if str, found := orderMap[order]; found {
+		return str
+	}
+	return fmt.Sprintf("Order(%d)", order)
+}
+
+// Compare returns -1, 0, or +1 depending on whether u1 sorts before, equal
+// to, or after u2 in this Order.
+func (order Order) Compare(u1, u2 UUID) int {
+	return order.compare(u1.a[:], u2.a[:])
+}
+
+// Less returns true iff u1 sorts before u2 in this Order.
+func (order Order) Less(u1, u2 UUID) bool {
+	return order.compare(u1.a[:], u2.a[:]) < 0
+}
+
+func (order Order) indices() *[ByteLength]byte {
+	if indices, found := orderIndices[order]; found {
+		return indices
+	}
+	panic(fmt.Errorf("unknown value Order(%d)", order))
+}
+
+func (order Order) compare(p, q []byte) int {
+	for _, i := range order.indices() {
+		switch {
+		case p[i] < q[i]:
+			return -1
+		case p[i] > q[i]:
+			return 1
+		}
+	}
+	return 0
+}
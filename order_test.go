@@ -0,0 +1,45 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestOrder(t *testing.T) {
+	// u0 < u1 in standard order, but u1 < u0 in dense order.
+	u0 := MustFromString("11d3c015-c015-11d3-96a8-185e0fad6335")
+	u1 := MustFromString("c01511d3-c015-11d2-96a8-185e0fad6335")
+
+	type testrow struct {
+		order  Order
+		name   string
+		expect int
+	}
+	data := []testrow{
+		{StandardOrder, "StandardOrder", -1},
+		{DenseOrder, "DenseOrder", 1},
+		{SQLServerOrder, "SQLServerOrder", 1},
+	}
+	for _, row := range data {
+		t.Run(row.name, func(t *testing.T) {
+			if name := row.order.String(); row.name != name {
+				t.Errorf("wrong String: expected %q, got %q", row.name, name)
+			}
+			if actual := row.order.Compare(u0, u1); row.expect != actual {
+				t.Errorf("wrong Compare(u0, u1): expected %d, got %d", row.expect, actual)
+			}
+			if actual := row.order.Compare(u1, u0); -row.expect != actual {
+				t.Errorf("wrong Compare(u1, u0): expected %d, got %d", -row.expect, actual)
+			}
+			if actual := row.order.Compare(u0, u0); actual != 0 {
+				t.Errorf("wrong Compare(u0, u0): expected 0, got %d", actual)
+			}
+			if actual := row.order.Less(u0, u1); (row.expect < 0) != actual {
+				t.Errorf("wrong Less(u0, u1): expected %v, got %v", row.expect < 0, actual)
+			}
+		})
+	}
+
+	if name := Order(42).String(); name != "Order(42)" {
+		t.Errorf("wrong String: expected %q, got %q", "Order(42)", name)
+	}
+}
@@ -0,0 +1,352 @@
+package uuid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CaseMode selects which letter case a Parser accepts in hex digits.
+type CaseMode byte
+
+// CaseMode enum constants.
+const (
+	_ CaseMode = iota
+
+	// CaseAny: accept "a-f" and "A-F", even mixed within one input.
+	CaseAny
+
+	// CaseLower: accept "a-f" only.
+	CaseLower
+
+	// CaseUpper: accept "A-F" only.
+	CaseUpper
+)
+
+// Parser parses textual UUID representations more strictly than FromString,
+// for use on untrusted input.  FromString accepts hyphens anywhere, spaces
+// between digits, and base-64 padding; a Parser accepts only the exact
+// formats produced by MarshalText:
+//
+//   - Canonical: "8-4-4-4-12"
+//   - HashLike: exactly 32 hex digits
+//   - Bracketed and Registry: "{8-4-4-4-12}"
+//   - URN: "urn:uuid:8-4-4-4-12"
+//   - Dense: "@" followed by exactly 22 base-64 digits, "+" and "/" only
+//
+// The zero Parser accepts all of these formats, in any case, with no
+// surrounding whitespace.  Empty input is always rejected.  Parsed values
+// are checked against the ValidationMode of Preferences, as by FromString,
+// unless Versions or Variants say otherwise.  FromBytes applies the same
+// checks to binary input.
+type Parser struct {
+	// Formats lists the accepted formats.  If empty, all are accepted.
+	// Listing Registry without Bracketed accepts only uppercase
+	// "{8-4-4-4-12}".
+	Formats []TextMode
+
+	// TrimSpace allows leading and trailing whitespace.
+	TrimSpace bool
+
+	// Case restricts the letter case of hex digits.  Zero means CaseAny.
+	// It does not apply to Dense.
+	Case CaseMode
+
+	// Versions, if not empty, lists the accepted Versions, replacing the
+	// ValidationMode of Preferences.
+	Versions VersionSet
+
+	// Variants, if not empty, lists the accepted Variants, replacing the
+	// default of the ValidationMode of Preferences.
+	Variants []Variant
+
+	// RejectNil rejects the Nil UUID, which is otherwise always accepted.
+	RejectNil bool
+
+	// RejectMax rejects the Max UUID, in which every bit is set.
+	RejectMax bool
+
+	// Preferences are applied to each parsed UUID, as by Preferences.FromString.
+	Preferences Preferences
+}
+
+// FromString attempts to parse a textual UUID representation.
+func (p Parser) FromString(in string) (UUID, error) {
+	var uuid UUID
+	err := p.unmarshalText(&uuid, "FromString", []byte(in))
+	return uuid, err
+}
+
+// MustFromString parses a textual UUID representation, or panics if it cannot.
+func (p Parser) MustFromString(in string) UUID {
+	var uuid UUID
+	err := p.unmarshalText(&uuid, "MustFromString", []byte(in))
+	if err != nil {
+		panic(err)
+	}
+	return uuid
+}
+
+// FromBytes attempts to parse a binary UUID representation in the BinaryMode
+// of Preferences, checking the value against the same rules as FromString.
+// The input must be exactly 16 bytes long.  Formats, TrimSpace, and Case do
+// not apply.
+func (p Parser) FromBytes(in []byte) (UUID, error) {
+	var uuid UUID
+	uuid.SetPreferences(p.Preferences)
+	x := uuid.getBits()
+
+	// Empty input is rejected here, before unmarshalBinary reads it as Nil.
+	if len(in) != ByteLength {
+		return uuid, lengthError("Parser", "FromBytes", in)
+	}
+
+	// Decode without validating, then apply this Parser's own rules.
+	var tmp [ByteLength]byte
+	if err := unmarshalBinary("Parser", "FromBytes", tmp[:], in, Preferences{Validate: AcceptAny}.collapse(x)); err != nil {
+		return uuid, err
+	}
+	if v := p.policy(x).check(tmp[:]); v.kind != 0 {
+		return uuid, v.apply(makeParseError("Parser", "FromBytes", in, false))
+	}
+	copy(uuid.a[:], tmp[:])
+	return uuid, nil
+}
+
+// Detect attempts to parse a textual UUID representation, and reports the
+// TextMode whose format it was written in.
+func (p Parser) Detect(in string) (TextMode, error) {
+	var uuid UUID
+	uuid.SetPreferences(p.Preferences)
+	return p.parse("Detect", uuid.a[:], []byte(in), uuid.getBits())
+}
+
+func (p Parser) unmarshalText(uuid *UUID, methodName string, in []byte) error {
+	uuid.SetPreferences(p.Preferences)
+	tm, err := p.parse(methodName, uuid.a[:], in, uuid.getBits())
+	if err == nil {
+		uuid.echo(tm)
+	}
+	return err
+}
+
+func (p Parser) parse(methodName string, out, in []byte, x bits) (TextMode, error) {
+	var tmp [ByteLength]byte
+	tm, err := p.parseHelper(methodName, tmp[:], in)
+	if err != nil {
+		return 0, err
+	}
+	if v := p.policy(x).check(tmp[:]); v.kind != 0 {
+		return 0, v.apply(p.fail(methodName, in))
+	}
+	copy(out, tmp[:])
+	return tm, nil
+}
+
+func (p Parser) parseHelper(methodName string, out, in []byte) (TextMode, error) {
+	r := makeSliceReader(in)
+	if p.TrimSpace {
+		r.TrimLeading(isSpace)
+		for r.j > r.i && isSpace(in[r.j-1]) {
+			r.j--
+		}
+	}
+	if r.IsEOF() {
+		i := r.CurrentOffset()
+		err := p.fail(methodName, in).at(int(i), KindTooShort, "end of input", "UUID")
+		return 0, err.detailf("unexpected end of input at position %d, expected UUID", i)
+	}
+
+	var tm TextMode
+	switch {
+	case r.HasPrefix(atSign):
+		tm = Dense
+	case r.HasPrefix(urnPrefix):
+		tm = URN
+	case r.HasPrefix(openBracket):
+		tm = Bracketed
+		if strictIsUpper(in[r.i:r.j]) {
+			tm = Registry
+		}
+	case strings.IndexByte(string(in[r.i:r.j]), '-') >= 0:
+		tm = Canonical
+	default:
+		tm = HashLike
+	}
+	if !p.accepts(tm) {
+		got, expected := textModeName(tm), p.formatList()
+		err := p.fail(methodName, in).at(int(r.CurrentOffset()), KindBadFormat, got, expected)
+		return 0, err.detailf("format %s is not accepted, expected %s", got, expected)
+	}
+
+	if tm == Dense {
+		return tm, p.parseDense(methodName, out, in, r)
+	}
+	return tm, p.parseHex(methodName, out, in, r, tm)
+}
+
+func (p Parser) parseHex(methodName string, out, in []byte, r sliceReader, tm TextMode) error {
+	var layout string
+	switch tm {
+	case Canonical:
+		layout = strictCanonical
+	case HashLike:
+		layout = strictHashLike
+	case URN:
+		layout = string(urnPrefix) + strictCanonical
+	default:
+		layout = "{" + strictCanonical + "}"
+	}
+
+	caseMode := p.Case
+	if tm == Registry && !p.accepts(Bracketed) {
+		caseMode = CaseUpper
+	}
+	var expectHex string
+	switch caseMode {
+	case CaseLower:
+		expectHex = "0-9 or a-f"
+	case CaseUpper:
+		expectHex = "0-9 or A-F"
+	default:
+		expectHex = "0-9, A-F, or a-f"
+	}
+
+	var tmp [ByteLength]byte
+	n := 0
+	for k := 0; k < len(layout); k++ {
+		want := layout[k]
+		expected := expectHex
+		if want != 'x' {
+			expected = fmt.Sprintf("%q", want)
+		}
+		if r.IsEOF() {
+			return p.failEOF(methodName, in, r, expected)
+		}
+		ch := r.ReadByte()
+		if want != 'x' {
+			if ch != want {
+				return p.failByte(methodName, in, r, ch, expected)
+			}
+			continue
+		}
+		var nibble byte
+		switch {
+		case ch >= '0' && ch <= '9':
+			nibble = ch - '0'
+		case ch >= 'a' && ch <= 'f' && caseMode != CaseUpper:
+			nibble = ch - 'a' + 0x0a
+		case ch >= 'A' && ch <= 'F' && caseMode != CaseLower:
+			nibble = ch - 'A' + 0x0a
+		default:
+			return p.failByte(methodName, in, r, ch, expected)
+		}
+		tmp[n/2] = (tmp[n/2] << 4) | nibble
+		n++
+	}
+	if !r.IsEOF() {
+		return p.failByte(methodName, in, r, r.ReadByte(), "end of input")
+	}
+	importStandard(out, tmp[:])
+	return nil
+}
+
+func (p Parser) parseDense(methodName string, out, in []byte, r sliceReader) error {
+	r.TrimPrefix(uint(len(atSign)))
+	body := r
+	for k := 0; k < strictDenseDigits; k++ {
+		if r.IsEOF() {
+			return p.failEOF(methodName, in, r, fmt.Sprintf("%d more base-64 digits", strictDenseDigits-k))
+		}
+		ch := r.ReadByte()
+		if !isStrictBase64(ch) {
+			return p.failByte(methodName, in, r, ch, "A-Z, a-z, 0-9, +, or /")
+		}
+	}
+	if !r.IsEOF() {
+		return p.failByte(methodName, in, r, r.ReadByte(), "end of input")
+	}
+	return unmarshalTextDense("Parser", methodName, out, &body)
+}
+
+func (p Parser) policy(x bits) policy {
+	pol := makePolicy(x, x.lenient())
+	pol.versions = p.Versions
+	pol.variants = p.Variants
+	pol.rejectNil = p.RejectNil
+	pol.rejectMax = p.RejectMax
+	return pol
+}
+
+func (p Parser) accepts(tm TextMode) bool {
+	if len(p.Formats) == 0 {
+		return true
+	}
+	for _, f := range p.Formats {
+		if f == tm || (f == Bracketed && tm == Registry) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Parser) formatList() string {
+	names := make([]string, len(p.Formats))
+	for i, f := range p.Formats {
+		names[i] = textModeName(f)
+	}
+	return joinOr(names)
+}
+
+func (p Parser) fail(methodName string, in []byte) ParseError {
+	return makeParseError("Parser", methodName, in, true)
+}
+
+func (p Parser) failByte(methodName string, in []byte, r sliceReader, ch byte, expected string) ParseError {
+	i := r.CurrentOffset() - 1
+	kind := KindBadByte
+	if expected == "end of input" {
+		kind = KindTrailingData
+	}
+	err := p.fail(methodName, in).at(int(i), kind, fmt.Sprintf("%q", ch), expected)
+	return err.detailf("unexpected byte %q %#02x at position %d, expected %s", ch, ch, i, expected)
+}
+
+func (p Parser) failEOF(methodName string, in []byte, r sliceReader, expected string) ParseError {
+	i := r.CurrentOffset()
+	err := p.fail(methodName, in).at(int(i), KindTooShort, "end of input", expected)
+	return err.detailf("unexpected end of input at position %d, expected %s", i, expected)
+}
+
+const (
+	strictCanonical   = "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+	strictHashLike    = "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"
+	strictDenseDigits = 22
+)
+
+func isStrictBase64(ch byte) bool {
+	return (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') || ch == '+' || ch == '/'
+}
+
+// strictIsUpper returns true iff in has uppercase hex digits and no
+// lowercase ones, i.e. it is written like Registry.
+func strictIsUpper(in []byte) bool {
+	hasUpper, hasLower := false, false
+	for _, ch := range in {
+		switch {
+		case ch >= 'A' && ch <= 'F':
+			hasUpper = true
+		case ch >= 'a' && ch <= 'f':
+			hasLower = true
+		}
+	}
+	return hasUpper && !hasLower
+}
+
+func textModeName(tm TextMode) string {
+	for name, mode := range textModeNames {
+		if mode == tm {
+			return name
+		}
+	}
+	return fmt.Sprintf("TextMode(%d)", tm)
+}
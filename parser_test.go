@@ -0,0 +1,227 @@
+package uuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParser(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	upper := strings.ToUpper(text)
+	dense := "@EeiKtHe5nOqWqBheD61jNQ"
+	expected := MustFromString(text)
+
+	type testrow struct {
+		name    string
+		parser  Parser
+		input   string
+		tm      TextMode
+		errText string
+	}
+	data := []testrow{
+		{"canonical", Parser{}, text, Canonical, ""},
+		{"hashlike", Parser{}, dehyphened(text), HashLike, ""},
+		{"bracketed", Parser{}, bracketed(text), Bracketed, ""},
+		{"registry", Parser{}, bracketed(upper), Registry, ""},
+		{"urn", Parser{}, urned(text), URN, ""},
+		{"dense", Parser{}, dense, Dense, ""},
+		{"mixed case", Parser{}, "77B99cea-8ab4-11e8-96a8-185e0fad6335", Canonical, ""},
+		{"trimmed", Parser{TrimSpace: true}, " \t" + text + "\n", Canonical, ""},
+		{"upper", Parser{Case: CaseUpper}, upper, Canonical, ""},
+		{"listed", Parser{Formats: []TextMode{URN, Dense}}, dense, Dense, ""},
+		{"registry only", Parser{Formats: []TextMode{Registry}}, bracketed(upper), Registry, ""},
+		{"bracketed allows registry", Parser{Formats: []TextMode{Bracketed}}, bracketed(upper), Registry, ""},
+		{
+			"empty",
+			Parser{},
+			"",
+			0,
+			`uuid.Parser.FromString: failed to parse "": unexpected end of input at position 0, expected UUID`,
+		},
+		{
+			"loose hyphens",
+			Parser{},
+			"7-7b99cea8ab411e896a8185e0fad6335",
+			0,
+			`uuid.Parser.FromString: failed to parse "7-7b99cea8ab411e896a8185e0fad6335": unexpected byte '-' 0x2d at position 1, expected 0-9, A-F, or a-f`,
+		},
+		{
+			"inner space",
+			Parser{TrimSpace: true},
+			"77b99cea -8ab4-11e8-96a8-185e0fad6335",
+			0,
+			`uuid.Parser.FromString: failed to parse "77b99cea -8ab4-11e8-96a8-185e0fad6335": unexpected byte ' ' 0x20 at position 8, expected '-'`,
+		},
+		{
+			"leading space",
+			Parser{},
+			" " + text,
+			0,
+			`uuid.Parser.FromString: failed to parse " 77b99cea-8ab4-11e8-96a8-185e0fad6335": unexpected byte ' ' 0x20 at position 0, expected 0-9, A-F, or a-f`,
+		},
+		{
+			"too short",
+			Parser{},
+			text[:35],
+			0,
+			`uuid.Parser.FromString: failed to parse "77b99cea-8ab4-11e8-96a8-185e0fad633": unexpected end of input at position 35, expected 0-9, A-F, or a-f`,
+		},
+		{
+			"trailing data",
+			Parser{},
+			bracketed(text) + "}",
+			0,
+			`uuid.Parser.FromString: failed to parse "{77b99cea-8ab4-11e8-96a8-185e0fad6335}}": unexpected byte '}' 0x7d at position 38, expected end of input`,
+		},
+		{
+			"lower",
+			Parser{Case: CaseLower},
+			upper,
+			0,
+			`uuid.Parser.FromString: failed to parse "77B99CEA-8AB4-11E8-96A8-185E0FAD6335": unexpected byte 'B' 0x42 at position 2, expected 0-9 or a-f`,
+		},
+		{
+			"not listed",
+			Parser{Formats: []TextMode{Canonical, URN}},
+			dehyphened(text),
+			0,
+			`uuid.Parser.FromString: failed to parse "77b99cea8ab411e896a8185e0fad6335": format hashlike is not accepted, expected canonical or urn`,
+		},
+		{
+			"registry only rejects lowercase",
+			Parser{Formats: []TextMode{Registry}},
+			bracketed(text),
+			0,
+			`uuid.Parser.FromString: failed to parse "{77b99cea-8ab4-11e8-96a8-185e0fad6335}": format bracketed is not accepted, expected registry`,
+		},
+		{
+			"dense padding",
+			Parser{},
+			dense + "==",
+			0,
+			`uuid.Parser.FromString: failed to parse "@EeiKtHe5nOqWqBheD61jNQ==": unexpected byte '=' 0x3d at position 23, expected end of input`,
+		},
+		{
+			"dense url alphabet",
+			Parser{},
+			"@EeiKtHe5nOqWqBheD61j_Q",
+			0,
+			`uuid.Parser.FromString: failed to parse "@EeiKtHe5nOqWqBheD61j_Q": unexpected byte '_' 0x5f at position 21, expected A-Z, a-z, 0-9, +, or /`,
+		},
+		{
+			"dense too short",
+			Parser{},
+			dense[:20],
+			0,
+			`uuid.Parser.FromString: failed to parse "@EeiKtHe5nOqWqBheD61": unexpected end of input at position 20, expected 3 more base-64 digits`,
+		},
+		{
+			"dense trailing bits",
+			Parser{},
+			"@EeiKtHe5nOqWqBheD61jNR",
+			0,
+			`uuid.Parser.FromString: failed to parse "@EeiKtHe5nOqWqBheD61jNR": unexpected data at end of input, expected "NQAA" but got "NRAA"`,
+		},
+	}
+	for _, row := range data {
+		t.Run(row.name, func(t *testing.T) {
+			u, err := row.parser.FromString(row.input)
+			switch {
+			case err != nil && row.errText == "":
+				t.Errorf("failed to FromString: %v", err)
+			case err == nil && row.errText != "":
+				t.Errorf("unexpected success at FromString: %v", u)
+			case err != nil:
+				if msg := err.Error(); row.errText != msg {
+					t.Errorf("wrong error: expected %q, got %q", row.errText, msg)
+				}
+			default:
+				checkEqual(t, "FromString", true, expected, u)
+				if tm, _ := row.parser.Detect(row.input); tm != row.tm {
+					t.Errorf("wrong Detect: expected %v, got %v", row.tm, tm)
+				}
+			}
+		})
+	}
+
+	p := Parser{Preferences: Preferences{Text: Canonical, Echo: EchoOn}}
+	u := p.MustFromString(urned(text))
+	checkString(t, "String with Echo", urned(text), u.String())
+}
+
+func TestParser_Policy(t *testing.T) {
+	v1 := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	v4 := "6ba7b810-9dad-41d1-80b4-00c04fd430c8"
+	ncs := "6ba7b810-9dad-41d1-60b4-00c04fd430c8"
+	nilText := "00000000-0000-0000-0000-000000000000"
+	maxText := "ffffffff-ffff-ffff-ffff-ffffffffffff"
+
+	public := Parser{Versions: VersionsOf(V4), RejectNil: true, RejectMax: true}
+	internal := Parser{Versions: VersionsOf(V1)}
+
+	type testrow struct {
+		name   string
+		parser Parser
+		input  string
+		detail string
+	}
+	data := []testrow{
+		{"public v4", public, v4, ""},
+		{"public v1", public, v1, "expected version V4, got V1"},
+		{"public nil", public, nilText, "Nil UUID is rejected by RejectNil"},
+		{"public max", public, maxText, "Max UUID is rejected by RejectMax"},
+		{"public ncs", public, ncs, "expected VariantRFC4122, got VariantNCS"},
+		{"internal v1", internal, v1, ""},
+		{"internal v4", internal, v4, "expected version V1, got V4"},
+		{"internal nil", internal, nilText, ""},
+		{"internal max", internal, maxText, ""},
+		{"variants", Parser{Variants: []Variant{VariantNCS, VariantMicrosoft}}, ncs, ""},
+		{"variants rfc", Parser{Variants: []Variant{VariantNCS, VariantMicrosoft}}, v4, "expected VariantNCS or VariantMicrosoft, got VariantRFC4122"},
+	}
+	for _, row := range data {
+		t.Run(row.name, func(t *testing.T) {
+			check := func(opName string, u UUID, err error) {
+				t.Helper()
+				switch {
+				case err != nil && row.detail == "":
+					t.Errorf("failed to %s: %v", opName, err)
+				case err == nil && row.detail != "":
+					t.Errorf("unexpected success at %s: %v", opName, u)
+				case err != nil:
+					if detail := err.(ParseError).Detail; detail != row.detail {
+						t.Errorf("wrong Detail at %s: expected %q, got %q", opName, row.detail, detail)
+					}
+				}
+			}
+
+			u, err := row.parser.FromString(row.input)
+			check("FromString", u, err)
+
+			parser := row.parser
+			parser.Preferences = Preferences{Binary: StandardOnly}
+			binary := Preferences{Validate: AcceptAny}.MustFromString(row.input).a
+			u, err = parser.FromBytes(binary[:])
+			check("FromBytes", u, err)
+		})
+	}
+}
+
+func TestParser_FromBytesLength(t *testing.T) {
+	data := [][]byte{
+		nil,
+		{},
+		{0x77, 0xb9, 0x9c, 0xea},
+		make([]byte, ByteLength+1),
+	}
+	for _, in := range data {
+		if u, err := (Parser{}).FromBytes(in); err == nil {
+			t.Errorf("unexpected success at FromBytes %x: %v", in, u)
+		} else if !errors.Is(err, ErrTooShort) && !errors.Is(err, ErrTrailingData) {
+			t.Errorf("wrong error at FromBytes %x: %v", in, err)
+		}
+	}
+	if _, err := (Parser{}).FromBytes(make([]byte, ByteLength)); err != nil {
+		t.Errorf("failed to FromBytes of 16 zero bytes: %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package uuid
+
+import (
+	"fmt"
+)
+
+var nullWord = []byte(`NULL`)
+
+// marshalPGArray writes n elements in the PostgreSQL array text format, e.g.
+// `{a,b,NULL}`.  The elem callback writes the i'th element, or returns false
+// for a NULL element.
+func marshalPGArray(n int, elem func(w *sliceWriter, i int) bool) string {
+	tmp := makeSliceWriter(bufferLength)
+	defer tmp.release()
+
+	out := make([]byte, 0, 2+n*(ByteLength*3))
+	out = append(out, '{')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		tmp.i = 0
+		if !elem(&tmp, i) {
+			out = append(out, nullWord...)
+			continue
+		}
+		item := tmp.Bytes()
+		if !needsPGQuote(item) {
+			out = append(out, item...)
+			continue
+		}
+		out = append(out, '"')
+		for _, ch := range item {
+			if ch == '"' || ch == '\\' {
+				out = append(out, '\\')
+			}
+			out = append(out, ch)
+		}
+		out = append(out, '"')
+	}
+	out = append(out, '}')
+	return string(out)
+}
+
+func needsPGQuote(item []byte) bool {
+	if len(item) == 0 || equalFoldASCII(item, nullWord) {
+		return true
+	}
+	for _, ch := range item {
+		switch ch {
+		case '{', '}', ',', '"', '\\':
+			return true
+		}
+		if isSpace(ch) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePGArray splits a one-dimensional PostgreSQL array literal into its
+// elements.  NULL elements are returned as nil slices, whereas empty
+// elements are returned as non-nil empty slices.
+func parsePGArray(typeName, methodName string, in []byte) ([][]byte, error) {
+	r := makeSliceReader(in)
+	r.TrimLeading(isSpace)
+	if r.IsEOF() {
+		i := r.CurrentOffset()
+		err := makeParseError(typeName, methodName, in, true).at(int(i), KindTooShort, "end of input", "'{'")
+		return nil, err.detailf("expected '{' at position %d", i)
+	}
+	if ch := r.ReadByte(); ch != '{' {
+		i := r.CurrentOffset() - 1
+		err := makeParseError(typeName, methodName, in, true).at(int(i), KindBadByte, fmt.Sprintf("%q", ch), "'{'")
+		return nil, err.detailf("expected '{' at position %d", i)
+	}
+	r.TrimLeading(isSpace)
+	if r.HasPrefix(closeBracket) {
+		r.TrimPrefix(1)
+		return parsePGArrayEnd(typeName, methodName, in, &r, [][]byte{})
+	}
+
+	var out [][]byte
+	for {
+		r.TrimLeading(isSpace)
+		if r.IsEOF() {
+			i := r.CurrentOffset()
+			err := makeParseError(typeName, methodName, in, true).at(int(i), KindTooShort, "end of input", "element")
+			return nil, err.detailf("unexpected end of input at position %d, expected element", i)
+		}
+
+		start := r.CurrentOffset()
+		ch := r.ReadByte()
+		switch {
+		case ch == '{':
+			err := makeParseError(typeName, methodName, in, true).at(int(start), KindBadByte, "'{'", "element")
+			return nil, err.detailf("unexpected '{' at position %d, nested arrays are not supported", start)
+
+		case ch == '"':
+			item := make([]byte, 0, r.Remain())
+			closed := false
+			for !closed && !r.IsEOF() {
+				ch = r.ReadByte()
+				switch {
+				case ch == '"':
+					closed = true
+				case ch == '\\' && !r.IsEOF():
+					item = append(item, r.ReadByte())
+				default:
+					item = append(item, ch)
+				}
+			}
+			if !closed {
+				i := r.CurrentOffset()
+				err := makeParseError(typeName, methodName, in, true).at(int(i), KindTooShort, "end of input", "'\"'")
+				return nil, err.detailf("unexpected end of input at position %d, expected '\"'", i)
+			}
+			out = append(out, item)
+			r.TrimLeading(isSpace)
+
+		default:
+			r.Unread(1)
+			for !r.IsEOF() {
+				ch = r.ReadByte()
+				if ch == ',' || ch == '}' {
+					r.Unread(1)
+					break
+				}
+				if ch == '"' || ch == '{' {
+					i := r.CurrentOffset() - 1
+					err := makeParseError(typeName, methodName, in, true).at(int(i), KindBadByte, fmt.Sprintf("%q", ch), "unquoted element")
+					return nil, err.detailf("unexpected %q at position %d in unquoted element", ch, i)
+				}
+			}
+			item := trimSpace(in[start:r.CurrentOffset()])
+			if len(item) == 0 {
+				err := makeParseError(typeName, methodName, in, true).at(int(start), KindBadByte, fmt.Sprintf("%q", in[r.CurrentOffset()]), "element")
+				return nil, err.detailf("unexpected empty element at position %d", start)
+			}
+			if equalFoldASCII(item, nullWord) {
+				item = nil
+			} else {
+				item = copyBytes(item)
+			}
+			out = append(out, item)
+		}
+
+		if r.IsEOF() {
+			i := r.CurrentOffset()
+			err := makeParseError(typeName, methodName, in, true).at(int(i), KindTooShort, "end of input", "',' or '}'")
+			return nil, err.detailf("unexpected end of input at position %d, expected ',' or '}'", i)
+		}
+		ch = r.ReadByte()
+		if ch == '}' {
+			return parsePGArrayEnd(typeName, methodName, in, &r, out)
+		}
+		if ch != ',' {
+			i := r.CurrentOffset() - 1
+			err := makeParseError(typeName, methodName, in, true).at(int(i), KindBadByte, fmt.Sprintf("%q", ch), "',' or '}'")
+			return nil, err.detailf("unexpected %q at position %d, expected ',' or '}'", ch, i)
+		}
+	}
+}
+
+func parsePGArrayEnd(typeName, methodName string, in []byte, r *sliceReader, out [][]byte) ([][]byte, error) {
+	r.TrimLeading(isSpace)
+	if !r.IsEOF() {
+		i := r.CurrentOffset()
+		err := makeParseError(typeName, methodName, in, true).at(int(i), KindTrailingData, fmt.Sprintf("%q", in[i]), "end of input")
+		return nil, err.detailf("unexpected data at position %d, expected end of input", i)
+	}
+	return out, nil
+}
+
+func trimSpace(in []byte) []byte {
+	i, j := 0, len(in)
+	for i < j && isSpace(in[i]) {
+		i++
+	}
+	for i < j && isSpace(in[j-1]) {
+		j--
+	}
+	return in[i:j]
+}
+
+func equalFoldASCII(p, q []byte) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		a, b := p[i], q[i]
+		if a >= 'a' && a <= 'z' {
+			a -= 'a' - 'A'
+		}
+		if b >= 'a' && b <= 'z' {
+			b -= 'a' - 'A'
+		}
+		if a != b {
+			return false
+		}
+	}
+	return true
+}
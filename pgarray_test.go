@@ -0,0 +1,98 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMarshalPGArray(t *testing.T) {
+	type testrow struct {
+		name   string
+		items  []string
+		expect string
+	}
+	data := []testrow{
+		{"empty", nil, `{}`},
+		{"plain", []string{"a", "@b+/"}, `{a,@b+/}`},
+		{"null", []string{"a", "\x00"}, `{a,NULL}`},
+		{"quoted", []string{"{a}", "", "null", "a b", `x"y\z`}, `{"{a}","","null","a b","x\"y\\z"}`},
+	}
+	for _, row := range data {
+		t.Run(row.name, func(t *testing.T) {
+			actual := marshalPGArray(len(row.items), func(w *sliceWriter, i int) bool {
+				if row.items[i] == "\x00" {
+					return false
+				}
+				w.WriteString(row.items[i])
+				return true
+			})
+			checkString(t, "marshalPGArray", row.expect, actual)
+		})
+	}
+}
+
+func TestParsePGArray(t *testing.T) {
+	type testrow struct {
+		input    string
+		items    []string
+		success  bool
+		kind     ErrorKind
+		position int
+	}
+	data := []testrow{
+		{`{}`, []string{}, true, 0, -1},
+		{` { } `, []string{}, true, 0, -1},
+		{`{a}`, []string{"a"}, true, 0, -1},
+		{`{a,b}`, []string{"a", "b"}, true, 0, -1},
+		{`{ a , b }`, []string{"a", "b"}, true, 0, -1},
+		{`{a,NULL,null}`, []string{"a", "\x00", "\x00"}, true, 0, -1},
+		{`{"{a}","","NULL","x\"y\\z"}`, []string{"{a}", "", "NULL", `x"y\z`}, true, 0, -1},
+		{``, nil, false, KindTooShort, 0},
+		{`a`, nil, false, KindBadByte, 0},
+		{`{`, nil, false, KindTooShort, 1},
+		{`{a`, nil, false, KindTooShort, 2},
+		{`{a,}`, nil, false, KindBadByte, 3},
+		{`{"a}`, nil, false, KindTooShort, 4},
+		{`{{a}}`, nil, false, KindBadByte, 1},
+		{`{a"b}`, nil, false, KindBadByte, 2},
+		{`{"a"b}`, nil, false, KindBadByte, 4},
+		{`{a}b`, nil, false, KindTrailingData, 3},
+	}
+	for _, row := range data {
+		t.Run(row.input, func(t *testing.T) {
+			items, err := parsePGArray("", "", []byte(row.input))
+			switch {
+			case err != nil && row.success:
+				t.Errorf("failed to parsePGArray %q: %v", row.input, err)
+
+			case err == nil && !row.success:
+				t.Errorf("unexpected success at parsePGArray %q: %q", row.input, items)
+
+			case err != nil:
+				pe := err.(ParseError)
+				if pe.Kind != row.kind || pe.Position != row.position {
+					t.Errorf("wrong error for parsePGArray %q: expected %v at %d, got %v at %d", row.input, row.kind, row.position, pe.Kind, pe.Position)
+				}
+				if !errors.Is(err, row.kind.Sentinel()) {
+					t.Errorf("errors.Is(%v, %v) is false", err, row.kind.Sentinel())
+				}
+
+			default:
+				if len(items) != len(row.items) {
+					t.Errorf("flubbed parsePGArray %q: expected %d items, got %d", row.input, len(row.items), len(items))
+					return
+				}
+				for i, item := range items {
+					expect := row.items[i]
+					if expect == "\x00" {
+						if item != nil {
+							t.Errorf("flubbed parsePGArray %q: expected item %d to be NULL, got %q", row.input, i, item)
+						}
+						continue
+					}
+					checkText(t, "parsePGArray", expect, item)
+				}
+			}
+		})
+	}
+}
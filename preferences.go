@@ -1,6 +1,10 @@
 package uuid
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
 
 // ValueMode selects the output behavior of the SQL-oriented Value method.
 type ValueMode byte
@@ -47,6 +51,30 @@ const (
 	// DenseFirst: produce "dense" bytes, parse either byte ordering.
 	// Ambiguous inputs will be interpreted as "dense".
 	DenseFirst
+
+	// GUIDOnly: produce Microsoft GUID bytes, parse Microsoft GUID bytes.
+	// This is the mixed-endian layout used by SQL Server uniqueidentifier,
+	// COM, and .NET Guid.ToByteArray(), in which the first three fields
+	// are little-endian.
+	GUIDOnly
+
+	// SQLServerOnly: produce "dense" bytes shuffled into the positions that
+	// SQL Server compares first, parse the same.  SQL Server sorts
+	// uniqueidentifier by bytes 10-15, then 8-9, 6-7, 4-5, and 0-3, so V1
+	// UUIDs stored this way sort chronologically, like NEWSEQUENTIALID().
+	SQLServerOnly
+)
+
+// MySQL 8's UUID_TO_BIN(u, 1) swaps the time_low and time_high fields, which
+// yields exactly the "dense" byte order.  These aliases exist so that code
+// which stores keys generated by MySQL can say so.
+const (
+	// MySQLOnly: produce UUID_TO_BIN(u, 1) bytes, parse UUID_TO_BIN(u, 1) bytes.
+	MySQLOnly = DenseOnly
+
+	// MySQLFirst: produce UUID_TO_BIN(u, 1) bytes, parse either byte ordering.
+	// Ambiguous inputs will be interpreted as UUID_TO_BIN(u, 1).
+	MySQLFirst = DenseFirst
 )
 
 func (bm BinaryMode) asByte() byte {
@@ -81,6 +109,13 @@ const (
 
 	// URN: URN format, e.g. "urn:uuid:77b99cea-8ab4-11e8-96a8-185e0fad6335"
 	URN
+
+	// Registry: uppercase "{8-4-4-4-12}", e.g. "{77B99CEA-8AB4-11E8-96A8-185E0FAD6335}"
+	Registry
+
+	// Inherit: only valid for the per-channel overrides JSON, SQL, and Fmt.
+	// The channel uses the Text mode.
+	Inherit
 )
 
 func (tm TextMode) asByte() byte {
@@ -94,11 +129,138 @@ func (tm TextMode) String() string {
 	return string(tm.asByte())
 }
 
+// NilMode selects how the Nil UUID is serialized.
+type NilMode byte
+
+// NilMode enum constants.
+const (
+	_ NilMode = iota
+
+	// NilZero: output is all zeroes, e.g. "@AAAAAAAAAAAAAAAAAAAAAA" or 16 zero bytes.
+	NilZero
+
+	// NilNull: Value outputs SQL NULL, and MarshalJSON outputs JSON null.
+	NilNull
+
+	// NilEmpty: textual output is an empty string, and binary output is an empty byte slice.
+	NilEmpty
+
+	// NilJSONNull: MarshalJSON outputs JSON null, other output is all zeroes.
+	NilJSONNull
+)
+
+func (nm NilMode) asByte() byte {
+	if ch, found := nmMap[nm]; found {
+		return ch
+	}
+	return '!'
+}
+
+func (nm NilMode) String() string {
+	return string(nm.asByte())
+}
+
+// EchoMode selects whether parsing text remembers the format of the input.
+type EchoMode byte
+
+// EchoMode enum constants.
+const (
+	_ EchoMode = iota
+
+	// EchoOff: parsing text leaves the TextMode unchanged.
+	EchoOff
+
+	// EchoOn: parsing text sets the TextMode to the format of the input, so
+	// that the UUID is written back out the way it was read.  Empty input,
+	// which parses as the Nil UUID, leaves the TextMode unchanged.
+	EchoOn
+)
+
+func (em EchoMode) asByte() byte {
+	if ch, found := emMap[em]; found {
+		return ch
+	}
+	return '!'
+}
+
+func (em EchoMode) String() string {
+	return string(em.asByte())
+}
+
+// ValidationMode selects which Versions and Variants are accepted when
+// parsing, from text and binary alike.  The Nil and Max UUIDs are always
+// accepted.  To accept only some Versions, use a Parser.
+type ValidationMode byte
+
+// ValidationMode enum constants.
+const (
+	_ ValidationMode = iota
+
+	// RejectInvalid: accept the RFC 9562 versions V1-V8 with VariantRFC4122
+	// only.  The loose binary modes StandardFirst and DenseFirst, and
+	// GUIDOnly, also accept VariantMicrosoft, from text as well as binary.
+	RejectInvalid
+
+	// AcceptAny: accept any 128-bit value.
+	AcceptAny
+)
+
+func (vm ValidationMode) asByte() byte {
+	if ch, found := valMap[vm]; found {
+		return ch
+	}
+	return '!'
+}
+
+func (vm ValidationMode) String() string {
+	return string(vm.asByte())
+}
+
+// gDefaultBits holds the bits used by UUIDs whose Preferences were never
+// set.  Readers load it atomically; writers also hold gDefaultMu so that
+// concurrent SetDefaultPreferences calls merge rather than race.
+var gDefaultBits = uint32(bitsDefault)
+var gDefaultMu sync.Mutex
+
+// DefaultPreferences returns the Preferences used by UUIDs whose Preferences
+// were never set, such as the zero UUID and the results of New and FromString.
+func DefaultPreferences() Preferences {
+	return bits(atomic.LoadUint32(&gDefaultBits)).expand()
+}
+
+// SetDefaultPreferences changes the Preferences used by UUIDs whose
+// Preferences were never set.  Zero-valued modes leave the corresponding
+// default unchanged.  UUIDs already carrying Preferences are not affected.
+//
+// It is safe to call concurrently, but it is intended to be called once
+// during program initialization.
+func SetDefaultPreferences(pref Preferences) {
+	gDefaultMu.Lock()
+	defer gDefaultMu.Unlock()
+	old := bits(atomic.LoadUint32(&gDefaultBits))
+	atomic.StoreUint32(&gDefaultBits, uint32(pref.collapse(old)))
+}
+
 // Preferences represents a combination of modes to apply to a UUID.
+//
+// JSON, SQL, and Fmt optionally override Text for MarshalJSON, for textual
+// Value output, and for String and the "fmt" package, respectively.  An
+// override of Inherit falls back to Text.
+//
+// Echo selects whether parsing text replaces Text with the format of the
+// input; see EchoOn.
+//
+// Validate selects which parsed values are accepted.
 type Preferences struct {
-	Value  ValueMode
-	Binary BinaryMode
-	Text   TextMode
+	Value    ValueMode
+	Binary   BinaryMode
+	Text     TextMode
+	NilAs    NilMode
+	JSON     TextMode
+	SQL      TextMode
+	Fmt      TextMode
+	Echo     EchoMode
+	Validate ValidationMode
 }
 
 // Nil returns a Nil-valued UUID with these preferences.
@@ -108,6 +270,14 @@ func (pref Preferences) Nil() UUID {
 	return uuid
 }
 
+// Max returns a Max-valued UUID with these preferences.
+func (pref Preferences) Max() UUID {
+	var uuid UUID
+	uuid.SetPreferences(pref)
+	uuid.SetMax()
+	return uuid
+}
+
 // New returns a newly generated V1 UUID with these preferences.
 func (pref Preferences) New() UUID {
 	var uuid UUID
@@ -116,6 +286,14 @@ func (pref Preferences) New() UUID {
 	return uuid
 }
 
+// NewSequential returns a newly generated sequential V1 UUID with these preferences.
+func (pref Preferences) NewSequential() UUID {
+	var uuid UUID
+	uuid.SetPreferences(pref)
+	uuid.SetNewSequential()
+	return uuid
+}
+
 // FromBytes attempts to parse a binary UUID representation using these preferences.
 func (pref Preferences) FromBytes(in []byte) (UUID, error) {
 	var uuid UUID
@@ -139,7 +317,7 @@ func (pref Preferences) MustFromBytes(in []byte) UUID {
 func (pref Preferences) FromString(in string) (UUID, error) {
 	var uuid UUID
 	uuid.SetPreferences(pref)
-	err := unmarshalText("Preferences", "FromString", uuid.a[:], []byte(in))
+	err := uuid.unmarshalText("Preferences", "FromString", []byte(in))
 	return uuid, err
 }
 
@@ -147,19 +325,38 @@ func (pref Preferences) FromString(in string) (UUID, error) {
 func (pref Preferences) MustFromString(in string) UUID {
 	var uuid UUID
 	uuid.SetPreferences(pref)
-	err := unmarshalText("Preferences", "MustFromString", uuid.a[:], []byte(in))
+	err := uuid.unmarshalText("Preferences", "MustFromString", []byte(in))
 	if err != nil {
 		panic(err)
 	}
 	return uuid
 }
 
+// String returns a compact code such as "TdDZ", with one character each for
+// Value, Binary, Text, and NilAs.  If any override is set, the code for the
+// JSON, SQL, and Fmt overrides follows after a colon, e.g. "TdDZ:C-B".  A
+// trailing "+" or "." means that Echo is EchoOn or EchoOff, e.g. "TdDZ+".
+// A ValidationMode follows after a slash, e.g. "TdDZ/A".  Modes which are
+// not set print as '-' or, for Echo and Validate, not at all.
+//
+// The Preferences returned by UUID.Preferences and DefaultPreferences have
+// every mode set, so they always print in full, e.g. "TdDZ:III./R".
 func (pref Preferences) String() string {
-	var buf [3]byte
+	buf := make([]byte, 4, 11)
 	buf[0] = pref.Value.asByte()
 	buf[1] = pref.Binary.asByte()
 	buf[2] = pref.Text.asByte()
-	return string(buf[:])
+	buf[3] = pref.NilAs.asByte()
+	if pref.JSON != 0 || pref.SQL != 0 || pref.Fmt != 0 {
+		buf = append(buf, ':', pref.JSON.asByte(), pref.SQL.asByte(), pref.Fmt.asByte())
+	}
+	if pref.Echo != 0 {
+		buf = append(buf, pref.Echo.asByte())
+	}
+	if pref.Validate != 0 {
+		buf = append(buf, '/', pref.Validate.asByte())
+	}
+	return string(buf)
 }
 
 func (pref Preferences) collapse(old bits) (x bits) {
@@ -187,48 +384,157 @@ func (pref Preferences) collapse(old bits) (x bits) {
 		x |= bitBinaryIsDense
 	case DenseFirst:
 		x |= bitBinaryIsDense | bitBinaryIsLoose
+	case GUIDOnly:
+		x |= bitBinaryIsGUID
+	case SQLServerOnly:
+		x |= binaryModeSQLServer
 	default:
 		panic(fmt.Errorf("unknown value BinaryMode(%d)", pref.Binary))
 	}
 
-	switch pref.Text {
-	case 0:
+	if pref.Text == 0 {
 		x |= old.just(bitsText)
-	case Canonical:
-		x |= textModeCanonical
-	case HashLike:
-		x |= textModeHashLike
-	case Bracketed:
-		x |= textModeBracketed
-	case URN:
-		x |= textModeURN
-	case Dense:
-		x |= bitTextIsDense
-	default:
+	} else if y, found := textModeBits[pref.Text]; found {
+		x |= y
+	} else {
 		panic(fmt.Errorf("unknown value TextMode(%d)", pref.Text))
 	}
 
+	x |= collapseOverride(pref.JSON, old, channelJSON)
+	x |= collapseOverride(pref.SQL, old, channelSQL)
+	x |= collapseOverride(pref.Fmt, old, channelFmt)
+
+	switch pref.NilAs {
+	case 0:
+		x |= old.just(bitsNil)
+	case NilZero:
+		x |= nilModeZero
+	case NilNull:
+		x |= nilModeNull
+	case NilEmpty:
+		x |= nilModeEmpty
+	case NilJSONNull:
+		x |= nilModeJSONNull
+	default:
+		panic(fmt.Errorf("unknown value NilMode(%d)", pref.NilAs))
+	}
+
+	switch pref.Echo {
+	case 0:
+		x |= old.just(bitTextEcho)
+	case EchoOff:
+		// pass
+	case EchoOn:
+		x |= bitTextEcho
+	default:
+		panic(fmt.Errorf("unknown value EchoMode(%d)", pref.Echo))
+	}
+
+	switch pref.Validate {
+	case 0:
+		x |= old.just(bitsValidation)
+	case RejectInvalid:
+		x |= validationReject
+	case AcceptAny:
+		x |= validationAny
+	default:
+		panic(fmt.Errorf("unknown value ValidationMode(%d)", pref.Validate))
+	}
+
 	return
 }
 
-type bits byte
+// collapseOverride encodes a per-channel TextMode override in the 3 bits
+// at the channel's shift: 0 for Inherit, or the TextMode itself.
+func collapseOverride(tm TextMode, old bits, ch channel) bits {
+	mask := bitsOverride << ch
+	switch {
+	case tm == 0:
+		return old.just(mask)
+	case tm == Inherit:
+		return 0
+	}
+	if _, found := textModeBits[tm]; !found {
+		panic(fmt.Errorf("unknown value TextMode(%d)", tm))
+	}
+	return bits(tm) << ch
+}
+
+func expandOverride(x bits, ch channel) TextMode {
+	tm := TextMode((x >> ch) & bitsOverride)
+	if tm == 0 {
+		return Inherit
+	}
+	return tm
+}
+
+type bits uint32
+
+// packedBits holds the low 24 bits of a bits value, which is all of them.
+// A UUID stores its bits this way so that it stays 19 bytes long.
+type packedBits [3]byte
+
+func (x bits) pack() packedBits {
+	return packedBits{byte(x), byte(x >> 8), byte(x >> 16)}
+}
+
+func (p packedBits) unpack() bits {
+	return bits(p[0]) | bits(p[1])<<8 | bits(p[2])<<16
+}
+
+// channel selects a serialization channel with its own TextMode override.
+// Its value is the shift of the override within bits.
+type channel uint
+
+const (
+	channelJSON channel = 12
+	channelSQL  channel = 15
+	channelFmt  channel = 18
+)
+
+// forChannel returns a copy of x whose text bits are replaced by the
+// channel's override, if it has one.
+func (x bits) forChannel(ch channel) bits {
+	tm := TextMode((x >> ch) & bitsOverride)
+	if tm == 0 {
+		return x
+	}
+	return (x &^ bitsText) | textModeBits[tm]
+}
+
+const (
+	bitValid         bits = 0x0080
+	bitValueIsBinary bits = 0x0040
+	bitBinaryIsDense bits = 0x0020
+	bitBinaryIsLoose bits = 0x0010
+	bitTextIsDense   bits = 0x0008
+	bitTextIsModeX   bits = 0x0004
+	bitTextIsModeY   bits = 0x0002
+	bitReserved      bits = 0x0001
+	bitNilIsModeX    bits = 0x0100
+	bitNilIsModeY    bits = 0x0200
+	bitBinaryIsGUID  bits = 0x0400
+	bitTextIsUpper   bits = 0x0800
+	bitTextEcho      bits = 0x200000
+)
 
 const (
-	bitValid         bits = 0x80
-	bitValueIsBinary bits = 0x40
-	bitBinaryIsDense bits = 0x20
-	bitBinaryIsLoose bits = 0x10
-	bitTextIsDense   bits = 0x08
-	bitTextIsModeX   bits = 0x04
-	bitTextIsModeY   bits = 0x02
-	bitReserved      bits = 0x01
+	bitsValidation   bits = 0x400000
+	validationReject bits = 0
+	validationAny    bits = bitsValidation
 )
 
 const (
 	bitsDefault    = bitValid | bitBinaryIsDense | bitBinaryIsLoose | bitTextIsDense
-	bitsBinary     = bitBinaryIsDense | bitBinaryIsLoose
-	bitsText       = bitTextIsDense | bitTextIsModeX | bitTextIsModeY
+	bitsBinary     = bitBinaryIsDense | bitBinaryIsLoose | bitBinaryIsGUID
+	bitsText       = bitTextIsDense | bitTextIsUpper | bitTextIsModeX | bitTextIsModeY
 	bitsTextIsMode = bitTextIsModeX | bitTextIsModeY
+	bitsNil        = bitNilIsModeX | bitNilIsModeY
+	bitsOverride   = bits(0x7)
+)
+
+const (
+	binaryModeSQLServer bits = bitBinaryIsGUID | bitBinaryIsDense
 )
 
 const (
@@ -236,6 +542,24 @@ const (
 	textModeHashLike  bits = bitTextIsModeX
 	textModeBracketed bits = bitTextIsModeY
 	textModeURN       bits = bitTextIsModeX | bitTextIsModeY
+	textModeRegistry  bits = bitTextIsUpper | bitTextIsModeY
+)
+
+// textModeBits maps each concrete TextMode to its bits.  Inherit is absent.
+var textModeBits = map[TextMode]bits{
+	Dense:     bitTextIsDense,
+	Canonical: textModeCanonical,
+	HashLike:  textModeHashLike,
+	Bracketed: textModeBracketed,
+	URN:       textModeURN,
+	Registry:  textModeRegistry,
+}
+
+const (
+	nilModeZero     bits = 0
+	nilModeNull     bits = bitNilIsModeX
+	nilModeEmpty    bits = bitNilIsModeY
+	nilModeJSONNull bits = bitNilIsModeX | bitNilIsModeY
 )
 
 func (x bits) String() string {
@@ -243,7 +567,7 @@ func (x bits) String() string {
 }
 
 func (x bits) GoString() string {
-	return fmt.Sprintf("%08b", byte(x))
+	return fmt.Sprintf("%032b", uint32(x))
 }
 
 func (x bits) just(y bits) bits {
@@ -258,7 +582,7 @@ func (x bits) defaulted() bits {
 	if x.has(bitValid) {
 		return x
 	}
-	return bitsDefault
+	return bits(atomic.LoadUint32(&gDefaultBits))
 }
 
 func (x bits) expand() (pref Preferences) {
@@ -278,31 +602,71 @@ func (x bits) expand() (pref Preferences) {
 		pref.Binary = DenseOnly
 	case bitBinaryIsDense | bitBinaryIsLoose:
 		pref.Binary = DenseFirst
+	case bitBinaryIsGUID:
+		pref.Binary = GUIDOnly
+	case binaryModeSQLServer:
+		pref.Binary = SQLServerOnly
 	}
 
-	switch x.just(bitsText) {
-	case textModeCanonical:
-		pref.Text = Canonical
-	case textModeHashLike:
-		pref.Text = HashLike
-	case textModeBracketed:
-		pref.Text = Bracketed
-	case textModeURN:
-		pref.Text = URN
-
-	case bitTextIsDense | textModeCanonical:
-		fallthrough
-	case bitTextIsDense | textModeHashLike:
-		fallthrough
-	case bitTextIsDense | textModeBracketed:
-		fallthrough
-	case bitTextIsDense | textModeURN:
-		pref.Text = Dense
+	pref.Text = expandText(x)
+	pref.JSON = expandOverride(x, channelJSON)
+	pref.SQL = expandOverride(x, channelSQL)
+	pref.Fmt = expandOverride(x, channelFmt)
+
+	switch x.just(bitsNil) {
+	case nilModeZero:
+		pref.NilAs = NilZero
+	case nilModeNull:
+		pref.NilAs = NilNull
+	case nilModeEmpty:
+		pref.NilAs = NilEmpty
+	case nilModeJSONNull:
+		pref.NilAs = NilJSONNull
+	}
+
+	if x.has(bitTextEcho) {
+		pref.Echo = EchoOn
+	} else {
+		pref.Echo = EchoOff
+	}
+
+	switch x.just(bitsValidation) {
+	case validationReject:
+		pref.Validate = RejectInvalid
+	case validationAny:
+		pref.Validate = AcceptAny
 	}
 
 	return
 }
 
+func expandText(x bits) TextMode {
+	if x.has(bitTextIsDense) {
+		return Dense
+	}
+	y := x.just(bitsText)
+	for tm, z := range textModeBits {
+		if y == z {
+			return tm
+		}
+	}
+	return 0
+}
+
+// lenient returns true iff x accepts VariantMicrosoft as well as
+// VariantRFC4122.  Text and binary parsing both ask this, so that whatever
+// one accepts, the other does too.
+func (x bits) lenient() bool {
+	return x.has(bitBinaryIsLoose) || x.just(bitsBinary) == bitBinaryIsGUID
+}
+
+func (x bits) order() Order {
+	if x.has(bitBinaryIsDense) {
+		return DenseOrder
+	}
+	return StandardOrder
+}
+
 func (x bits) textLength() (used uint, peak uint) {
 	switch x.just(bitsText) {
 	case textModeCanonical:
@@ -317,6 +681,9 @@ func (x bits) textLength() (used uint, peak uint) {
 	case textModeURN:
 		return 45, 45
 
+	case textModeRegistry:
+		return 38, 38
+
 	default:
 		return 23, 25
 	}
@@ -334,6 +701,28 @@ var bmMap = map[BinaryMode]byte{
 	StandardFirst: 's',
 	DenseOnly:     'D',
 	DenseFirst:    'd',
+	GUIDOnly:      'G',
+	SQLServerOnly: 'Q',
+}
+
+var nmMap = map[NilMode]byte{
+	0:           '-',
+	NilZero:     'Z',
+	NilNull:     'N',
+	NilEmpty:    'E',
+	NilJSONNull: 'J',
+}
+
+var emMap = map[EchoMode]byte{
+	0:       '-',
+	EchoOff: '.',
+	EchoOn:  '+',
+}
+
+var valMap = map[ValidationMode]byte{
+	0:             '-',
+	RejectInvalid: 'R',
+	AcceptAny:     'A',
 }
 
 var tmMap = map[TextMode]byte{
@@ -343,4 +732,6 @@ var tmMap = map[TextMode]byte{
 	HashLike:  'H',
 	Bracketed: 'B',
 	URN:       'U',
+	Registry:  'R',
+	Inherit:   'I',
 }
@@ -2,6 +2,7 @@ package uuid
 
 import (
 	"testing"
+	"unsafe"
 )
 
 func TestBitsDefaulted(t *testing.T) {
@@ -27,3 +28,78 @@ func TestBitsDefaulted(t *testing.T) {
 		})
 	}
 }
+
+func TestBitsSize(t *testing.T) {
+	// Preferences ride along in every UUID, so they must stay small.
+	if size := unsafe.Sizeof(UUID{}); size > ByteLength+3 {
+		t.Errorf("UUID is %d bytes, expected at most %d", size, ByteLength+3)
+	}
+	all := bitValid | bitValueIsBinary | bitsBinary | bitsText | bitReserved | bitsNil |
+		bitTextEcho | bitsValidation |
+		bitsOverride<<channelJSON | bitsOverride<<channelSQL | bitsOverride<<channelFmt
+	if x := all.pack().unpack(); x != all {
+		t.Errorf("packing loses bits: expected %#x, got %#x", uint32(all), uint32(x))
+	}
+}
+
+func TestDefaultPreferences(t *testing.T) {
+	saved := DefaultPreferences()
+	defer SetDefaultPreferences(saved)
+
+	if expected := bitsDefault.expand(); saved != expected {
+		t.Fatalf("wrong initial DefaultPreferences: expected %v, got %v", expected, saved)
+	}
+
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	standardBytes := []byte{
+		0x77, 0xb9, 0x9c, 0xea,
+		0x8a, 0xb4, 0x11, 0xe8,
+		0x96, 0xa8, 0x18, 0x5e,
+		0x0f, 0xad, 0x63, 0x35,
+	}
+
+	explicit := MustFromString(text)
+	explicit.SetPreferences(Preferences{Value: Text, Binary: DenseOnly, Text: Dense})
+
+	SetDefaultPreferences(Preferences{Binary: StandardOnly, Text: Canonical})
+	checkPrefs(t, "DefaultPreferences", Text, StandardOnly, Canonical, DefaultPreferences().Nil())
+	if nm := DefaultPreferences().NilAs; nm != NilZero {
+		t.Errorf("wrong NilAs: expected %v, got %v", NilZero, nm)
+	}
+
+	var zero UUID
+	checkPrefs(t, "zero UUID", Text, StandardOnly, Canonical, zero)
+	checkPrefs(t, "New", Text, StandardOnly, Canonical, New())
+
+	parsed := MustFromString(text)
+	checkString(t, "String", text, parsed.String())
+	checkValue(t, "Value", text, justValue(parsed.Value()))
+
+	var scanned UUID
+	if err := scanned.Scan(standardBytes); err != nil {
+		t.Errorf("failed to Scan: %v", err)
+	}
+	checkString(t, "Scan", text, scanned.CanonicalString())
+
+	var decoded UUID
+	if err := decoded.UnmarshalJSON([]byte(quoted(text))); err != nil {
+		t.Errorf("failed to UnmarshalJSON: %v", err)
+	}
+	checkMarshalJSON(t, "MarshalJSON", quoted(text), decoded)
+
+	checkPrefs(t, "explicit", Text, DenseOnly, Dense, explicit)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetDefaultPreferences(Preferences{Value: Binary})
+			SetDefaultPreferences(Preferences{Value: Text})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		zero.Preferences()
+	}
+	<-done
+	checkPrefs(t, "after concurrent Set", Text, StandardOnly, Canonical, DefaultPreferences().Nil())
+}
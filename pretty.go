@@ -0,0 +1,143 @@
+package uuid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pretty renders the error over several lines for display to a human: the
+// message, then the input with a caret under the byte at Position, then what
+// was expected, then any hints about what the input may have been meant to
+// be, e.g.
+//
+//	uuid.FromString: failed to parse "EeiKtHe5nOqWqBheD61jNQ": unexpected byte 'i' 0x69 at position 2, expected 0-9, A-F, a-f, or -
+//	  EeiKtHe5nOqWqBheD61jNQ
+//	    ^
+//	  expected 0-9, A-F, a-f, or -, got 'i'
+//	  did you mean "@EeiKtHe5nOqWqBheD61jNQ"?
+func (err ParseError) Pretty() string {
+	var buf strings.Builder
+	buf.WriteString(err.Error())
+	buf.WriteByte('\n')
+
+	isText := err.Input == "" || strings.HasPrefix(err.Input, `"`)
+	line, columns := renderInput(err.ExactInput, isText)
+	if err.ExactInput != nil {
+		buf.WriteString("  ")
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if err.Position >= 0 && err.Position <= len(err.ExactInput) {
+		buf.WriteString("  ")
+		buf.WriteString(strings.Repeat(" ", columns[err.Position]))
+		buf.WriteString("^\n")
+	}
+	if err.Expected != "" {
+		fmt.Fprintf(&buf, "  expected %s", err.Expected)
+		if err.Got != "" {
+			fmt.Fprintf(&buf, ", got %s", err.Got)
+		}
+		buf.WriteByte('\n')
+	}
+	if isText {
+		for _, hint := range textHints(err.ExactInput) {
+			buf.WriteString("  ")
+			buf.WriteString(hint)
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.String()
+}
+
+// renderInput renders in on one line, escaping any bytes that would not
+// print as a single column.  It also returns the column at which each byte
+// starts, plus the column just past the end.
+func renderInput(in []byte, isText bool) (string, []int) {
+	var buf strings.Builder
+	columns := make([]int, len(in)+1)
+	for i, ch := range in {
+		columns[i] = buf.Len()
+		switch {
+		case !isText:
+			if i > 0 {
+				buf.WriteByte(' ')
+				columns[i]++
+			}
+			fmt.Fprintf(&buf, "%02x", ch)
+		case ch >= 0x20 && ch < 0x7f:
+			buf.WriteByte(ch)
+		default:
+			fmt.Fprintf(&buf, `\x%02x`, ch)
+		}
+	}
+	columns[len(in)] = buf.Len()
+	if !isText && len(in) > 0 {
+		columns[len(in)]++
+	}
+	return buf.String(), columns
+}
+
+// textHints guesses at what a malformed textual UUID was meant to be.
+func textHints(in []byte) []string {
+	str := strings.TrimSpace(string(in))
+	var hints []string
+
+	// Candidate corrections, offered only if they parse.
+	var candidates []string
+	if len(str) >= 2 && str[0] == '"' && str[len(str)-1] == '"' {
+		candidates = append(candidates, str[1:len(str)-1])
+	}
+	if !strings.HasPrefix(str, "@") && len(strings.TrimRight(str, "=")) == strictDenseDigits {
+		candidates = append(candidates, "@"+str)
+	}
+	if strings.HasPrefix(str, "{") && !strings.HasSuffix(str, "}") {
+		candidates = append(candidates, str+"}")
+	}
+	if strings.HasSuffix(str, "}") && !strings.HasPrefix(str, "{") {
+		candidates = append(candidates, "{"+str)
+	}
+	if !strings.HasPrefix(str, "@") {
+		if fixed := lookalikeReplacer.Replace(str); fixed != str {
+			candidates = append(candidates, fixed)
+		}
+	}
+	for _, candidate := range candidates {
+		var tmp [ByteLength]byte
+		if _, err := detectTextHelper("", "", tmp[:], []byte(candidate)); err == nil && candidate != "" {
+			hints = append(hints, fmt.Sprintf("did you mean %q?", candidate))
+		}
+	}
+
+	// Descriptions of the shape of hex input.
+	body := strings.TrimPrefix(str, string(urnPrefix))
+	body = strings.TrimSuffix(strings.TrimPrefix(body, "{"), "}")
+	if body == "" || strings.HasPrefix(body, "@") {
+		return hints
+	}
+	groups := strings.Split(body, "-")
+	switch {
+	case len(groups) == len(canonicalGroups):
+		for i, group := range groups {
+			if len(group) != canonicalGroups[i] {
+				hints = append(hints, fmt.Sprintf("hint: group %d has %d hex digits, expected %d in 8-4-4-4-12", i+1, len(group), canonicalGroups[i]))
+			}
+		}
+	case len(groups) == 1 && len(body) != 2*ByteLength && isHexString(body):
+		hints = append(hints, fmt.Sprintf("hint: got %d hex digits, expected %d", len(body), 2*ByteLength))
+	}
+	return hints
+}
+
+var canonicalGroups = []int{8, 4, 4, 4, 12}
+
+var lookalikeReplacer = strings.NewReplacer("O", "0", "o", "0", "I", "1", "l", "1")
+
+func isHexString(str string) bool {
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		if !((ch >= '0' && ch <= '9') || (ch >= 'A' && ch <= 'F') || (ch >= 'a' && ch <= 'f')) {
+			return false
+		}
+	}
+	return true
+}
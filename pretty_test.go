@@ -0,0 +1,86 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestParseError_Pretty(t *testing.T) {
+	type testrow struct {
+		testName string
+		parse    func() error
+		expected string
+	}
+	data := []testrow{
+		{
+			testName: "dense without at sign",
+			parse:    func() error { _, err := FromString("EeiKtHe5nOqWqBheD61jNQ"); return err },
+			expected: `uuid.FromString: failed to parse "EeiKtHe5nOqWqBheD61jNQ": unexpected byte 'i' 0x69 at position 2, expected 0-9, A-F, a-f, or -` + "\n" +
+				"  EeiKtHe5nOqWqBheD61jNQ\n" +
+				"    ^\n" +
+				"  expected 0-9, A-F, a-f, or -, got 'i'\n" +
+				`  did you mean "@EeiKtHe5nOqWqBheD61jNQ"?` + "\n",
+		},
+		{
+			testName: "canonical missing a digit",
+			parse:    func() error { _, err := FromString("77b99cea-8ab4-11e8-96a8-185e0fad635"); return err },
+			expected: `uuid.FromString: failed to parse "77b99cea-8ab4-11e8-96a8-185e0fad635": unexpected end of input at position 35, expected 1 more hex digits` + "\n" +
+				"  77b99cea-8ab4-11e8-96a8-185e0fad635\n" +
+				"                                     ^\n" +
+				"  expected 1 more hex digits, got end of input\n" +
+				"  hint: group 5 has 11 hex digits, expected 12 in 8-4-4-4-12\n",
+		},
+		{
+			testName: "lookalike letters",
+			parse:    func() error { _, err := FromString("77b99cea-8ab4-11e8-96a8-185eOfad6335"); return err },
+			expected: `uuid.FromString: failed to parse "77b99cea-8ab4-11e8-96a8-185eOfad6335": unexpected byte 'O' 0x4f at position 28, expected 0-9, A-F, a-f, or -` + "\n" +
+				"  77b99cea-8ab4-11e8-96a8-185eOfad6335\n" +
+				"                              ^\n" +
+				"  expected 0-9, A-F, a-f, or -, got 'O'\n" +
+				`  did you mean "77b99cea-8ab4-11e8-96a8-185e0fad6335"?` + "\n",
+		},
+		{
+			testName: "hash-like too long",
+			parse:    func() error { _, err := FromString("77b99cea8ab411e896a8185e0fad63350"); return err },
+			expected: `uuid.FromString: failed to parse "77b99cea8ab411e896a8185e0fad63350": unexpected byte '0' 0x30 at position 32, expected end of input` + "\n" +
+				"  77b99cea8ab411e896a8185e0fad63350\n" +
+				"                                  ^\n" +
+				"  expected end of input, got '0'\n" +
+				"  hint: got 33 hex digits, expected 32\n",
+		},
+		{
+			testName: "control byte",
+			parse:    func() error { _, err := FromString("77b9\x019cea"); return err },
+			expected: `uuid.FromString: failed to parse "77b9\x019cea": unexpected byte '\x01' 0x01 at position 4, expected 0-9, A-F, a-f, or -` + "\n" +
+				`  77b9\x019cea` + "\n" +
+				"      ^\n" +
+				`  expected 0-9, A-F, a-f, or -, got '\x01'` + "\n",
+		},
+		{
+			testName: "binary",
+			parse:    func() error { _, err := FromBytes([]byte{0xde, 0xad, 0xca, 0xfe}); return err },
+			expected: "uuid.FromBytes: failed to parse [de ad ca fe]: expected 16 bytes, got 4\n" +
+				"  de ad ca fe\n" +
+				"              ^\n" +
+				"  expected 16 bytes, got 4 bytes\n",
+		},
+		{
+			testName: "bad version",
+			parse:    func() error { _, err := FromString("77b99cea-8ab4-01e8-96a8-185e0fad6335"); return err },
+			expected: `uuid.FromString: failed to parse "77b99cea-8ab4-01e8-96a8-185e0fad6335": expected version V1-V8, got V0` + "\n" +
+				"  77b99cea-8ab4-01e8-96a8-185e0fad6335\n" +
+				"  expected version V1-V8, got V0\n",
+		},
+	}
+	for _, row := range data {
+		t.Run(row.testName, func(t *testing.T) {
+			err := row.parse()
+			pe, ok := err.(ParseError)
+			if !ok {
+				t.Fatalf("expected ParseError, got %T %v", err, err)
+			}
+			if actual := pe.Pretty(); actual != row.expected {
+				t.Errorf("wrong Pretty:\nexpected:\n%s\ngot:\n%s", row.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+)
+
+// Set holds an unordered collection of distinct UUIDs, with a single set of
+// Preferences shared by the whole Set.
+//
+// Membership is decided by the 16 bytes of each UUID alone; the Preferences
+// carried by the UUIDs passed in are ignored.
+type Set struct {
+	m map[Key]struct{}
+	b bits
+}
+
+var _ json.Marshaler = Set{}
+var _ json.Marshaler = (*Set)(nil)
+var _ json.Unmarshaler = (*Set)(nil)
+
+var _ driver.Valuer = Set{}
+var _ driver.Valuer = (*Set)(nil)
+var _ sql.Scanner = (*Set)(nil)
+
+// NewSet returns a Set holding the given UUIDs.
+func NewSet(uuids ...UUID) Set {
+	set := Set{m: make(map[Key]struct{}, len(uuids))}
+	set.Add(uuids...)
+	return set
+}
+
+func (set Set) getBits() bits {
+	return set.b.defaulted()
+}
+
+// Preferences returns the preference knobs shared by every UUID in this Set.
+func (set Set) Preferences() Preferences {
+	return set.b.defaulted().expand()
+}
+
+// SetPreferences updates the preference knobs shared by every UUID in this Set.
+func (set *Set) SetPreferences(pref Preferences) {
+	set.b = pref.collapse(set.getBits())
+}
+
+// Len returns the number of UUIDs in this Set.
+func (set Set) Len() int {
+	return len(set.m)
+}
+
+// Contains returns true iff this Set holds the given UUID.
+func (set Set) Contains(uuid UUID) bool {
+	_, found := set.m[uuid.Key()]
+	return found
+}
+
+// Add inserts UUIDs into this Set.
+func (set *Set) Add(uuids ...UUID) {
+	if set.m == nil {
+		set.m = make(map[Key]struct{}, len(uuids))
+	}
+	for _, uuid := range uuids {
+		set.m[uuid.Key()] = struct{}{}
+	}
+}
+
+// Remove deletes UUIDs from this Set.
+func (set *Set) Remove(uuids ...UUID) {
+	for _, uuid := range uuids {
+		delete(set.m, uuid.Key())
+	}
+}
+
+// Union returns a new Set holding the UUIDs that are in either Set.
+// The result carries the Preferences of this Set.
+func (set Set) Union(other Set) Set {
+	out := set.empty(len(set.m) + len(other.m))
+	for key := range set.m {
+		out.m[key] = struct{}{}
+	}
+	for key := range other.m {
+		out.m[key] = struct{}{}
+	}
+	return out
+}
+
+// Intersection returns a new Set holding the UUIDs that are in both Sets.
+// The result carries the Preferences of this Set.
+func (set Set) Intersection(other Set) Set {
+	out := set.empty(0)
+	for key := range set.m {
+		if _, found := other.m[key]; found {
+			out.m[key] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Difference returns a new Set holding the UUIDs that are in this Set but
+// not in the other.  The result carries the Preferences of this Set.
+func (set Set) Difference(other Set) Set {
+	out := set.empty(0)
+	for key := range set.m {
+		if _, found := other.m[key]; !found {
+			out.m[key] = struct{}{}
+		}
+	}
+	return out
+}
+
+// List returns the contents of this Set as a List sorted in the given Order.
+// The List carries the Preferences of this Set.
+func (set Set) List(order Order) List {
+	keys := make([]Key, 0, len(set.m))
+	for key := range set.m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return order.compare(keys[i][:], keys[j][:]) < 0
+	})
+
+	list := MakeList(len(keys))
+	list.b = set.b
+	for _, key := range keys {
+		list.data = append(list.data, key[:]...)
+	}
+	return list
+}
+
+// Each calls fn for every UUID in this Set, in the given Order, until fn
+// returns false.
+func (set Set) Each(order Order, fn func(UUID) bool) {
+	list := set.List(order)
+	for i, n := 0, list.Len(); i < n; i++ {
+		if !fn(list.At(i)) {
+			return
+		}
+	}
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+// It produces a JSON array of textual UUID representations, sorted in the
+// Order that matches this Set's BinaryMode.
+func (set Set) MarshalJSON() ([]byte, error) {
+	list := set.List(set.getBits().order())
+	x := list.getBits()
+	out := make([]byte, 0, 2+list.Len()*(ByteLength*3))
+	out = append(out, '[')
+	for i, n := 0, list.Len(); i < n; i++ {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		w := makeSliceWriter(bufferLength)
+		marshalJSON(&w, list.record(i), x)
+		out = append(out, w.Bytes()...)
+		w.release()
+	}
+	out = append(out, ']')
+	return out, nil
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+// It attempts to parse a JSON array of textual UUID representations.
+func (set *Set) UnmarshalJSON(in []byte) error {
+	var items []string
+	if err := json.Unmarshal(in, &items); err != nil {
+		return makeParseError("Set", "UnmarshalJSON", in, true).at(-1, KindBadJSON, "", "").wrap(err).detailf("json.Unmarshal: %v", err)
+	}
+	m := make(map[Key]struct{}, len(items))
+	for i, item := range items {
+		var key Key
+		if err := unmarshalText("Set", "UnmarshalJSON", key[:], []byte(item), set.getBits()); err != nil {
+			return makeParseError("Set", "UnmarshalJSON", in, true).element(i, err)
+		}
+		m[key] = struct{}{}
+	}
+	set.m = m
+	return nil
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+// It produces a PostgreSQL array literal, sorted in the Order that matches
+// this Set's BinaryMode.
+func (set Set) Value() (driver.Value, error) {
+	return set.List(set.getBits().order()).Value()
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+// It attempts to interpret a PostgreSQL array literal as a Set of UUIDs.
+func (set *Set) Scan(value interface{}) error {
+	var list List
+	if err := list.Scan(value); err != nil {
+		if pe, ok := err.(ParseError); ok {
+			pe.TypeName = "Set"
+			return pe
+		}
+		if te, ok := err.(TypeError); ok {
+			te.TypeName = "Set"
+			return te
+		}
+		return err
+	}
+	m := make(map[Key]struct{}, list.Len())
+	for i, n := 0, list.Len(); i < n; i++ {
+		var key Key
+		copy(key[:], list.record(i))
+		m[key] = struct{}{}
+	}
+	set.m = m
+	return nil
+}
+
+func (set Set) empty(n int) Set {
+	return Set{m: make(map[Key]struct{}, n), b: set.b}
+}
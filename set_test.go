@@ -0,0 +1,105 @@
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	u0 := MustFromString("11d3c015-c015-11d3-96a8-185e0fad6335")
+	u1 := MustFromString("c01511d3-c015-11d2-96a8-185e0fad6335")
+	u2 := MustFromString("77b99cea-8ab4-11e8-96a8-185e0fad6335")
+
+	dupe := u0
+	dupe.SetPreferences(Preferences{Value: Binary, Binary: StandardOnly, Text: Canonical})
+
+	var set Set
+	set.Add(u0, u1, dupe)
+	if n := set.Len(); n != 2 {
+		t.Errorf("wrong Len: expected 2, got %d", n)
+	}
+	if !set.Contains(dupe) {
+		t.Errorf("expected Contains to ignore Preferences")
+	}
+	if set.Contains(u2) {
+		t.Errorf("unexpected Contains %s", u2.CanonicalString())
+	}
+
+	other := NewSet(u1, u2)
+	checkList(t, "Union", set.Union(other).List(StandardOrder), u0, u2, u1)
+	checkList(t, "Intersection", set.Intersection(other).List(StandardOrder), u1)
+	checkList(t, "Difference", set.Difference(other).List(StandardOrder), u0)
+	checkList(t, "Union DenseOrder", set.Union(other).List(DenseOrder), u1, u0, u2)
+
+	var visited []UUID
+	set.Union(other).Each(DenseOrder, func(u UUID) bool {
+		visited = append(visited, u)
+		return len(visited) < 2
+	})
+	checkList(t, "Each", ListOf(visited...), u1, u0)
+
+	set.Remove(dupe)
+	checkList(t, "Remove", set.List(StandardOrder), u1)
+}
+
+func TestSet_Serialization(t *testing.T) {
+	u0 := MustFromString("11d3c015-c015-11d3-96a8-185e0fad6335")
+	u1 := MustFromString("c01511d3-c015-11d2-96a8-185e0fad6335")
+
+	type testrow struct {
+		name  string
+		pref  Preferences
+		json  string
+		value string
+	}
+	data := []testrow{
+		{
+			name:  "dense",
+			pref:  Preferences{Text: Dense, Binary: DenseFirst},
+			json:  `["@EdLAFcAVEdOWqBheD61jNQ","@EdPAFRHTwBWWqBheD61jNQ"]`,
+			value: `{@EdLAFcAVEdOWqBheD61jNQ,@EdPAFRHTwBWWqBheD61jNQ}`,
+		},
+		{
+			name:  "canonical",
+			pref:  Preferences{Text: Canonical, Binary: StandardOnly},
+			json:  `["11d3c015-c015-11d3-96a8-185e0fad6335","c01511d3-c015-11d2-96a8-185e0fad6335"]`,
+			value: `{11d3c015-c015-11d3-96a8-185e0fad6335,c01511d3-c015-11d2-96a8-185e0fad6335}`,
+		},
+	}
+	for _, row := range data {
+		t.Run(row.name, func(t *testing.T) {
+			set := NewSet(u0, u1)
+			set.SetPreferences(row.pref)
+			checkText(t, "MarshalJSON", row.json, justBytes(set.MarshalJSON()))
+			checkValue(t, "Value", row.value, justValue(set.Value()))
+
+			var parsed Set
+			if err := json.Unmarshal([]byte(row.json), &parsed); err != nil {
+				t.Errorf("failed to UnmarshalJSON %q: %v", row.json, err)
+				return
+			}
+			checkList(t, "UnmarshalJSON", parsed.List(StandardOrder), u0, u1)
+
+			parsed = Set{}
+			if err := parsed.Scan(row.value); err != nil {
+				t.Errorf("failed to Scan %q: %v", row.value, err)
+				return
+			}
+			checkList(t, "Scan", parsed.List(StandardOrder), u0, u1)
+		})
+	}
+
+	var empty Set
+	checkText(t, "MarshalJSON", `[]`, justBytes(empty.MarshalJSON()))
+	if err := empty.UnmarshalJSON([]byte(`null`)); err != nil || empty.Len() != 0 {
+		t.Errorf("flubbed UnmarshalJSON null: %d items, %v", empty.Len(), err)
+	}
+	if err := empty.UnmarshalJSON([]byte(`["bogus"]`)); err == nil {
+		t.Errorf("unexpected success at UnmarshalJSON of bogus element")
+	}
+	if err := empty.Scan(42); err == nil {
+		t.Errorf("unexpected success at Scan of int")
+	} else if te, ok := err.(TypeError); !ok || te.TypeName != "Set" {
+		t.Errorf("wrong error for Scan of int: %#v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package uuid
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// preferencer is implemented by every type in this package whose
+// Preferences can be set: UUID, NullUUID, ID, TypeID, List, and Set.
+type preferencer interface {
+	Preferences() Preferences
+	SetPreferences(Preferences)
+}
+
+var preferencerType = reflect.TypeOf((*preferencer)(nil)).Elem()
+
+var valueModeNames = map[string]ValueMode{
+	"text":   Text,
+	"binary": Binary,
+}
+
+var binaryModeNames = map[string]BinaryMode{
+	"standard":      StandardOnly,
+	"standardfirst": StandardFirst,
+	"dense":         DenseOnly,
+	"densefirst":    DenseFirst,
+	"mysql":         MySQLOnly,
+	"mysqlfirst":    MySQLFirst,
+	"guid":          GUIDOnly,
+	"sqlserver":     SQLServerOnly,
+}
+
+var textModeNames = map[string]TextMode{
+	"dense":     Dense,
+	"canonical": Canonical,
+	"hashlike":  HashLike,
+	"bracketed": Bracketed,
+	"urn":       URN,
+	"registry":  Registry,
+	"inherit":   Inherit,
+}
+
+var nilModeNames = map[string]NilMode{
+	"zero":     NilZero,
+	"null":     NilNull,
+	"empty":    NilEmpty,
+	"jsonnull": NilJSONNull,
+}
+
+var validationModeNames = map[string]ValidationMode{
+	"reject": RejectInvalid,
+	"any":    AcceptAny,
+}
+
+var echoModeNames = map[string]EchoMode{
+	"off": EchoOff,
+	"on":  EchoOn,
+}
+
+// ApplyTags walks the struct that ptr points to and applies the Preferences
+// found in `uuid:"..."` struct tags to the tagged fields, e.g.
+//
+//	type Row struct {
+//		ID     uuid.UUID   `uuid:"value=binary,binary=dense"`
+//		Owners []uuid.UUID `uuid:"text=canonical,nil=null"`
+//	}
+//
+// The keys are "value", "binary", "text", "nil", the per-channel text
+// overrides "json", "sql", and "fmt", "echo", and "validate"; omitted keys
+// leave the corresponding mode unchanged.  A tag may be placed on a field of
+// any type with Preferences, or on a pointer, slice, or array of such, in
+// which case it applies to every element.  Untagged struct fields, pointers,
+// slices, and arrays are searched for further tags, as are embedded structs
+// even if unexported.  The tag `uuid:"-"` skips a field.
+func ApplyTags(ptr interface{}) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return makeTypeError("", "ApplyTags", ptr, (*struct{})(nil))
+	}
+	walker := tagWalker{seen: make(map[uintptr]struct{})}
+	return walker.walk(v.Elem(), v.Elem().Type().Name(), nil)
+}
+
+type tagWalker struct {
+	seen map[uintptr]struct{}
+}
+
+func (walker *tagWalker) walk(v reflect.Value, path string, pref *Preferences) error {
+	if v.CanAddr() && v.Addr().Type().Implements(preferencerType) {
+		if pref != nil {
+			v.Addr().Interface().(preferencer).SetPreferences(*pref)
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		if _, found := walker.seen[v.Pointer()]; found {
+			return nil
+		}
+		walker.seen[v.Pointer()] = struct{}{}
+		return walker.walk(v.Elem(), path, pref)
+
+	case reflect.Slice, reflect.Array:
+		for i, n := 0, v.Len(); i < n; i++ {
+			if err := walker.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i), pref); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+		for i, n := 0, t.NumField(); i < n; i++ {
+			f := t.Field(i)
+			fieldPath := f.Name
+			if path != "" {
+				fieldPath = path + "." + f.Name
+			}
+			tag, hasTag := f.Tag.Lookup("uuid")
+			if tag == "-" {
+				continue
+			}
+			if f.PkgPath != "" {
+				if hasTag {
+					return makeParseError("", "ApplyTags", []byte(tag), true).detailf("field %s: tag on unexported field", fieldPath)
+				}
+				// The exported fields of an embedded struct are promoted,
+				// even if the struct itself is unexported.
+				if !f.Anonymous || !isStructOrPtrStruct(f.Type) {
+					continue
+				}
+			}
+			var fieldPref *Preferences
+			if hasTag {
+				p, err := parseTag(makeParseError("", "ApplyTags", []byte(tag), true), []byte(tag))
+				if err != nil {
+					pe := err.(ParseError)
+					return pe.detailf("field %s: %s", fieldPath, pe.Detail)
+				}
+				if !holdsPreferences(f.Type) {
+					return makeParseError("", "ApplyTags", []byte(tag), true).detailf("field %s: tag on field of type %v, which has no Preferences", fieldPath, f.Type)
+				}
+				fieldPref = &p
+			}
+			if err := walker.walk(v.Field(i), fieldPath, fieldPref); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isStructOrPtrStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// holdsPreferences returns true iff values of type t have Preferences, or
+// are pointers, slices, or arrays of such.
+func holdsPreferences(t reflect.Type) bool {
+	for {
+		if reflect.PtrTo(t).Implements(preferencerType) {
+			return true
+		}
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		default:
+			return false
+		}
+	}
+}
+
+// parseTag parses the contents of a `uuid:"..."` struct tag, reporting any
+// failure with the names in fail.
+func parseTag(fail ParseError, tag []byte) (Preferences, error) {
+	var pref Preferences
+	if len(tag) == 0 {
+		return pref, nil
+	}
+	seen := make(map[string]bool, 4)
+	for start := 0; start <= len(tag); {
+		end := start + strings.IndexByte(string(tag[start:]), ',')
+		if end < start {
+			end = len(tag)
+		}
+		item := string(tag[start:end])
+		eq := strings.IndexByte(item, '=')
+		if eq < 0 {
+			err := failAt(fail, tag, end, "'='")
+			return pref, err.detailf("expected key=value, got %q", strings.TrimSpace(item))
+		}
+		key := strings.ToLower(strings.TrimSpace(item[:eq]))
+		value := strings.ToLower(strings.TrimSpace(item[eq+1:]))
+		keyPos := start + leadingSpace(item)
+		valuePos := start + eq + 1 + leadingSpace(item[eq+1:])
+		if seen[key] {
+			err := fail.at(keyPos, KindBadByte, fmt.Sprintf("%q", key), "a new key")
+			return pref, err.detailf("duplicate key %q", key)
+		}
+		seen[key] = true
+
+		var found bool
+		switch key {
+		case "value":
+			pref.Value, found = valueModeNames[value]
+		case "binary":
+			pref.Binary, found = binaryModeNames[value]
+		case "text":
+			pref.Text, found = textModeNames[value]
+		case "nil":
+			pref.NilAs, found = nilModeNames[value]
+		case "json":
+			pref.JSON, found = textModeNames[value]
+		case "sql":
+			pref.SQL, found = textModeNames[value]
+		case "fmt":
+			pref.Fmt, found = textModeNames[value]
+		case "echo":
+			pref.Echo, found = echoModeNames[value]
+		case "validate":
+			pref.Validate, found = validationModeNames[value]
+		default:
+			expected := "value, binary, text, nil, json, sql, fmt, echo, or validate"
+			err := fail.at(keyPos, KindBadByte, fmt.Sprintf("%q", key), expected)
+			return pref, err.detailf("unknown key %q, expected %s", key, expected)
+		}
+		if key == "text" && pref.Text == Inherit {
+			found = false
+		}
+		if !found {
+			err := failAt(fail, tag, valuePos, key+" mode")
+			if value != "" {
+				err.Got = fmt.Sprintf("%q", value)
+			}
+			return pref, err.detailf("unknown %s mode %q", key, value)
+		}
+		start = end + 1
+	}
+	return pref, nil
+}
+
+func leadingSpace(str string) int {
+	return len(str) - len(strings.TrimLeftFunc(str, unicode.IsSpace))
+}
@@ -0,0 +1,151 @@
+package uuid
+
+import (
+	"testing"
+)
+
+type tagsInner struct {
+	Ref UUID `uuid:"value=binary,binary=standard"`
+}
+
+type tagsRow struct {
+	ID       UUID     `uuid:"value=binary,binary=dense,text=canonical"`
+	Parent   *UUID    `uuid:"text=urn"`
+	Children []UUID   `uuid:"text=hashlike,nil=null"`
+	Pair     [2]UUID  `uuid:"text=bracketed"`
+	Owner    NullUUID `uuid:"text=registry,json=canonical"`
+	Tags     List     `uuid:"text=canonical"`
+	Inner    tagsInner
+	Inners   []*tagsInner
+	Plain    UUID
+	Skipped  UUID `uuid:"-"`
+	Self     *tagsRow
+	Name     string
+	private  UUID
+}
+
+type tagsEmbedded struct {
+	tagsInner
+	*tagsPointee
+	ID UUID `uuid:"text=urn"`
+}
+
+type tagsPointee struct {
+	Other UUID `uuid:"text=hashlike"`
+}
+
+func TestApplyTags_Embedded(t *testing.T) {
+	row := tagsEmbedded{tagsPointee: &tagsPointee{}}
+	if err := ApplyTags(&row); err != nil {
+		t.Fatalf("failed to ApplyTags: %v", err)
+	}
+	checkPrefs(t, "ID", Text, DenseFirst, URN, row.ID)
+	checkPrefs(t, "Ref", Binary, StandardOnly, Dense, row.Ref)
+	checkPrefs(t, "Other", Text, DenseFirst, HashLike, row.Other)
+
+	var empty tagsEmbedded
+	if err := ApplyTags(&empty); err != nil {
+		t.Errorf("failed to ApplyTags with nil embedded pointer: %v", err)
+	}
+}
+
+func TestApplyTags(t *testing.T) {
+	parent := New()
+	row := tagsRow{
+		Parent:   &parent,
+		Children: []UUID{New(), New()},
+		Inners:   []*tagsInner{{}, nil},
+	}
+	row.Self = &row
+	if err := ApplyTags(&row); err != nil {
+		t.Fatalf("failed to ApplyTags: %v", err)
+	}
+
+	checkPrefs(t, "ID", Binary, DenseOnly, Canonical, row.ID)
+	checkPrefs(t, "Parent", Text, DenseFirst, URN, *row.Parent)
+	checkPrefs(t, "Children[0]", Text, DenseFirst, HashLike, row.Children[0])
+	checkPrefs(t, "Children[1]", Text, DenseFirst, HashLike, row.Children[1])
+	if nm := row.Children[1].Preferences().NilAs; nm != NilNull {
+		t.Errorf("Children[1]: wrong NilAs: expected %v, got %v", NilNull, nm)
+	}
+	checkPrefs(t, "Pair[1]", Text, DenseFirst, Bracketed, row.Pair[1])
+	checkPrefs(t, "Owner", Text, DenseFirst, Registry, row.Owner.UUID)
+	if tm := row.Owner.Preferences().JSON; tm != Canonical {
+		t.Errorf("Owner: wrong JSON: expected %v, got %v", Canonical, tm)
+	}
+	if tm := row.Tags.Preferences().Text; tm != Canonical {
+		t.Errorf("Tags: wrong Text: expected %v, got %v", Canonical, tm)
+	}
+	checkPrefs(t, "Inner.Ref", Binary, StandardOnly, Dense, row.Inner.Ref)
+	checkPrefs(t, "Inners[0].Ref", Binary, StandardOnly, Dense, row.Inners[0].Ref)
+	if row.Plain.b != (packedBits{}) || row.Skipped.b != (packedBits{}) || row.private.b != (packedBits{}) {
+		t.Errorf("untagged fields were modified")
+	}
+
+	type testrow struct {
+		name     string
+		input    interface{}
+		expected string
+	}
+	data := []testrow{
+		{
+			"not a pointer",
+			tagsRow{},
+			`uuid.ApplyTags: wrong type uuid.tagsRow: expected one of *struct {}`,
+		},
+		{
+			"unknown key",
+			&struct {
+				ID UUID `uuid:"colour=blue"`
+			}{},
+			`uuid.ApplyTags: failed to parse "colour=blue": field ID: unknown key "colour", expected value, binary, text, nil, json, sql, fmt, echo, or validate`,
+		},
+		{
+			"unknown mode",
+			&struct {
+				ID UUID `uuid:"text=base64"`
+			}{},
+			`uuid.ApplyTags: failed to parse "text=base64": field ID: unknown text mode "base64"`,
+		},
+		{
+			"missing equals",
+			&struct {
+				ID UUID `uuid:"text"`
+			}{},
+			`uuid.ApplyTags: failed to parse "text": field ID: expected key=value, got "text"`,
+		},
+		{
+			"duplicate key",
+			&struct {
+				ID UUID `uuid:"text=urn,text=dense"`
+			}{},
+			`uuid.ApplyTags: failed to parse "text=urn,text=dense": field ID: duplicate key "text"`,
+		},
+		{
+			"wrong field type",
+			&struct {
+				Name []string `uuid:"text=urn"`
+			}{},
+			`uuid.ApplyTags: failed to parse "text=urn": field Name: tag on field of type []string, which has no Preferences`,
+		},
+		{
+			"unexported field",
+			&struct {
+				id UUID `uuid:"text=urn"`
+			}{},
+			`uuid.ApplyTags: failed to parse "text=urn": field id: tag on unexported field`,
+		},
+	}
+	for _, row := range data {
+		t.Run(row.name, func(t *testing.T) {
+			err := ApplyTags(row.input)
+			if err == nil {
+				t.Errorf("unexpected success at ApplyTags")
+				return
+			}
+			if actual := err.Error(); row.expected != actual {
+				t.Errorf("wrong error: expected %q, got %q", row.expected, actual)
+			}
+		})
+	}
+}
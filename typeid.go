@@ -0,0 +1,321 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// MaxPrefixLength is the maximum number of bytes in the prefix of a TypeID.
+const MaxPrefixLength = 63
+
+// suffixLength is the number of base-32 digits needed to hold 128 bits.
+const suffixLength = 26
+
+const base32Alphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// TypeID holds a UUID tagged with a lowercase prefix naming its entity type,
+// e.g. "user_01h455vb4pex5vsknk084sn02q".
+//
+// The suffix is the UUID in RFC 4122 byte order, as the TypeID specification
+// requires, encoded in lowercase Crockford's base-32.  The BinaryMode of its
+// Preferences does not apply.  Suffixes sort in the same order as the
+// standard bytes, so the V7 suffixes generated by NewTypeID, which lead with
+// a timestamp, sort in chronological order.
+type TypeID struct {
+	prefix string
+	uuid   UUID
+}
+
+var _ fmt.Stringer = TypeID{}
+var _ fmt.Stringer = (*TypeID)(nil)
+
+var _ encoding.TextMarshaler = TypeID{}
+var _ encoding.TextMarshaler = (*TypeID)(nil)
+var _ encoding.TextUnmarshaler = (*TypeID)(nil)
+
+var _ json.Marshaler = TypeID{}
+var _ json.Marshaler = (*TypeID)(nil)
+var _ json.Unmarshaler = (*TypeID)(nil)
+
+var _ driver.Valuer = TypeID{}
+var _ driver.Valuer = (*TypeID)(nil)
+var _ sql.Scanner = (*TypeID)(nil)
+
+// NewTypeID returns a newly generated V7 UUID tagged with the given prefix.
+func NewTypeID(prefix string) (TypeID, error) {
+	if err := checkPrefix("", "NewTypeID", prefix); err != nil {
+		return TypeID{}, err
+	}
+	return TypeID{prefix: prefix, uuid: NewV7()}, nil
+}
+
+// MustNewTypeID returns a newly generated V7 UUID tagged with the given prefix, or panics if the prefix is invalid.
+func MustNewTypeID(prefix string) TypeID {
+	id, err := NewTypeID(prefix)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// MakeTypeID tags an existing UUID with the given prefix.
+func MakeTypeID(prefix string, uuid UUID) (TypeID, error) {
+	if err := checkPrefix("", "MakeTypeID", prefix); err != nil {
+		return TypeID{}, err
+	}
+	return TypeID{prefix: prefix, uuid: uuid}, nil
+}
+
+// ParseTypeID attempts to parse a textual TypeID representation with any prefix.
+func ParseTypeID(in string) (TypeID, error) {
+	var id TypeID
+	err := id.unmarshalText("", "ParseTypeID", []byte(in))
+	return id, err
+}
+
+// ParseTypeIDWithPrefix attempts to parse a textual TypeID representation,
+// which must carry exactly the given prefix.  An empty prefix accepts only
+// input with no prefix.
+func ParseTypeIDWithPrefix(prefix, in string) (TypeID, error) {
+	if err := checkPrefix("", "ParseTypeIDWithPrefix", prefix); err != nil {
+		return TypeID{}, err
+	}
+	id := TypeID{prefix: prefix}
+	err := id.unmarshalTextExact("", "ParseTypeIDWithPrefix", []byte(in), true)
+	return id, err
+}
+
+// Prefix returns the entity type prefix of this TypeID.
+func (id TypeID) Prefix() string {
+	return id.prefix
+}
+
+// UUID returns the UUID held by this TypeID.
+func (id TypeID) UUID() UUID {
+	return id.uuid
+}
+
+// Preferences returns the preference knobs for this object.
+func (id TypeID) Preferences() Preferences {
+	return id.uuid.Preferences()
+}
+
+// SetPreferences updates the preference knobs for this object.
+func (id *TypeID) SetPreferences(pref Preferences) {
+	id.uuid.SetPreferences(pref)
+}
+
+// String fulfills the "fmt".Stringer interface.
+// It produces a textual representation of this TypeID.
+func (id TypeID) String() string {
+	w := makeSliceWriter(bufferLength)
+	defer w.release()
+	id.marshalText(&w)
+	return w.String()
+}
+
+// MarshalText fulfills the "encoding".TextMarshaler interface.
+// It produces a textual representation of this TypeID.
+func (id TypeID) MarshalText() ([]byte, error) {
+	w := makeSliceWriter(bufferLength)
+	defer w.release()
+	id.marshalText(&w)
+	return w.CopyBytes(), nil
+}
+
+// MarshalJSON fulfills the "encoding/json".Marshaler interface.
+// It produces a textual representation of this TypeID as a JSON string.
+func (id TypeID) MarshalJSON() ([]byte, error) {
+	w := makeSliceWriter(bufferLength)
+	defer w.release()
+	w.WriteByte('"')
+	id.marshalText(&w)
+	w.WriteByte('"')
+	return w.CopyBytes(), nil
+}
+
+// Value fulfills the "database/sql/driver".Valuer interface.
+// It always produces the textual representation, as the prefix would
+// otherwise be lost.
+func (id TypeID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// FromString attempts to parse a textual TypeID representation.
+// If this TypeID already has a prefix, the input must carry the same prefix.
+func (id *TypeID) FromString(in string) error {
+	return id.unmarshalText("TypeID", "FromString", []byte(in))
+}
+
+// UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
+// It attempts to parse a textual TypeID representation.
+func (id *TypeID) UnmarshalText(in []byte) error {
+	return id.unmarshalText("TypeID", "UnmarshalText", in)
+}
+
+// UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
+// It attempts to parse a JSON string as a textual TypeID representation.
+func (id *TypeID) UnmarshalJSON(in []byte) error {
+	var str string
+	if err := json.Unmarshal(in, &str); err != nil {
+		return makeParseError("TypeID", "UnmarshalJSON", in, true).at(-1, KindBadJSON, "", "").wrap(err).detailf("json.Unmarshal: %v", err)
+	}
+	return id.unmarshalText("TypeID", "UnmarshalJSON", []byte(str))
+}
+
+// Scan fulfills the "database/sql".Scanner interface.
+// It attempts to interpret a SQL value as a textual TypeID representation.
+// SQL NULL sets the UUID to the Nil UUID and leaves the prefix unchanged.
+func (id *TypeID) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		id.uuid.SetNil()
+		return nil
+	case []byte:
+		return id.unmarshalText("TypeID", "Scan", v)
+	case string:
+		return id.unmarshalText("TypeID", "Scan", []byte(v))
+	}
+	return makeTypeError("TypeID", "Scan", value, nil, []byte(nil), "")
+}
+
+func (id TypeID) marshalText(w *sliceWriter) {
+	var tmp [ByteLength]byte
+	marshalBinaryStandard(tmp[:], id.uuid.a[:])
+	if id.prefix != "" {
+		w.WriteString(id.prefix)
+		w.WriteByte('_')
+	}
+	encodeBase32(w.Grab(suffixLength), tmp[:])
+}
+
+func (id *TypeID) unmarshalText(typeName, methodName string, in []byte) error {
+	return id.unmarshalTextExact(typeName, methodName, in, id.prefix != "")
+}
+
+// unmarshalTextExact is like unmarshalText, except that if exact is true,
+// the input must carry the prefix of id even if that prefix is empty.
+func (id *TypeID) unmarshalTextExact(typeName, methodName string, in []byte, exact bool) error {
+	prefix, suffix, hasSep := splitTypeID(in)
+	if hasSep && len(prefix) == 0 {
+		err := makeParseError(typeName, methodName, in, true).at(0, KindBadByte, "'_'", "a-z")
+		return err.detailf("unexpected _ with empty prefix")
+	}
+	if err := checkPrefixBytes(typeName, methodName, in, prefix); err != nil {
+		return err
+	}
+	if exact && id.prefix != string(prefix) {
+		err := makeParseError(typeName, methodName, in, true).at(0, KindBadFormat, fmt.Sprintf("%q", prefix), fmt.Sprintf("%q", id.prefix))
+		return err.detailf("expected prefix %q, got %q", id.prefix, prefix)
+	}
+	start := len(in) - len(suffix)
+	if uint(len(suffix)) < suffixLength {
+		expected := fmt.Sprintf("%d more base-32 digits", suffixLength-uint(len(suffix)))
+		err := makeParseError(typeName, methodName, in, true).at(len(in), KindTooShort, "end of input", expected)
+		return err.detailf("expected %d base-32 digits after prefix, got %d", suffixLength, len(suffix))
+	}
+	if uint(len(suffix)) > suffixLength {
+		i := start + int(suffixLength)
+		err := makeParseError(typeName, methodName, in, true).at(i, KindTrailingData, fmt.Sprintf("%q", in[i]), "end of input")
+		return err.detailf("expected %d base-32 digits after prefix, got %d", suffixLength, len(suffix))
+	}
+
+	var tmp [ByteLength]byte
+	if err := decodeBase32(typeName, methodName, in, start, tmp[:]); err != nil {
+		return err
+	}
+	x := id.uuid.getBits()
+	if v := validate(tmp[:], x, x.lenient()); v.kind != 0 {
+		return v.apply(makeParseError(typeName, methodName, in, true))
+	}
+	importStandard(id.uuid.a[:], tmp[:])
+	id.prefix = string(prefix)
+	return nil
+}
+
+func splitTypeID(in []byte) (prefix, suffix []byte, hasSep bool) {
+	for i := len(in) - 1; i >= 0; i-- {
+		if in[i] == '_' {
+			return in[:i], in[i+1:], true
+		}
+	}
+	return nil, in, false
+}
+
+func checkPrefix(typeName, methodName, prefix string) error {
+	return checkPrefixBytes(typeName, methodName, []byte(prefix), []byte(prefix))
+}
+
+// checkPrefixBytes validates prefix, which begins at the start of in.
+func checkPrefixBytes(typeName, methodName string, in, prefix []byte) error {
+	n := len(prefix)
+	if n > MaxPrefixLength {
+		ch := in[MaxPrefixLength]
+		err := makeParseError(typeName, methodName, in, true).at(MaxPrefixLength, KindBadByte, fmt.Sprintf("%q", ch), "end of prefix")
+		return err.detailf("prefix is %d bytes long, expected at most %d", n, MaxPrefixLength)
+	}
+	for i, ch := range prefix {
+		if ch >= 'a' && ch <= 'z' {
+			continue
+		}
+		if ch == '_' && i > 0 && i < n-1 {
+			continue
+		}
+		err := makeParseError(typeName, methodName, in, true).at(i, KindBadByte, fmt.Sprintf("%q", ch), "a-z or interior _")
+		return err.detailf("unexpected byte %q %#02x in prefix at position %d, expected a-z or interior _", ch, ch, i)
+	}
+	return nil
+}
+
+// encodeBase32 writes the 16 bytes of in as 26 base-32 digits, most
+// significant first.  The 130-bit digit string carries 2 leading zero bits.
+func encodeBase32(out, in []byte) {
+	for i := uint(0); i < suffixLength; i++ {
+		var v byte
+		for k := uint(0); k < 5; k++ {
+			v <<= 1
+			bit := int(i*5+k) - 2
+			if bit >= 0 && (in[bit/8]&(0x80>>uint(bit%8))) != 0 {
+				v |= 1
+			}
+		}
+		out[i] = base32Alphabet[v]
+	}
+}
+
+// decodeBase32 decodes the 26 base-32 digits of in starting at start into
+// the 16 bytes of out.
+func decodeBase32(typeName, methodName string, in []byte, start int, out []byte) error {
+	zeroBytes(out)
+	for i, ch := range in[start:] {
+		v, ok := base32Value(ch)
+		if !ok {
+			expected := "0-9 or a-z except i, l, o, u"
+			err := makeParseError(typeName, methodName, in, true).at(start+i, KindBadByte, fmt.Sprintf("%q", ch), expected)
+			return err.detailf("unexpected byte %q %#02x in suffix at position %d, expected %s", ch, ch, i, expected)
+		}
+		if i == 0 && v > 7 {
+			err := makeParseError(typeName, methodName, in, true).at(start, KindBadByte, fmt.Sprintf("%q", ch), "0-7")
+			return err.detailf("suffix overflows 128 bits, expected first digit 0-7, got %q", ch)
+		}
+		for k := uint(0); k < 5; k++ {
+			bit := i*5 + int(k) - 2
+			if bit >= 0 && (v&(0x10>>k)) != 0 {
+				out[bit/8] |= 0x80 >> uint(bit%8)
+			}
+		}
+	}
+	return nil
+}
+
+func base32Value(ch byte) (byte, bool) {
+	for i := 0; i < len(base32Alphabet); i++ {
+		if base32Alphabet[i] == ch {
+			return byte(i), true
+		}
+	}
+	return 0, false
+}
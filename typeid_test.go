@@ -0,0 +1,211 @@
+package uuid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEncodeBase32(t *testing.T) {
+	// Test vector from the TypeID specification.
+	in := []byte{
+		0x01, 0x89, 0x0a, 0x5d,
+		0xac, 0x96, 0x77, 0x4b,
+		0xbc, 0xce, 0xb3, 0x02,
+		0x09, 0x9a, 0x80, 0x57,
+	}
+	expect := "01h455vb4pex5vsknk084sn02q"
+
+	var out [suffixLength]byte
+	encodeBase32(out[:], in)
+	checkString(t, "encodeBase32", expect, string(out[:]))
+
+	var back [ByteLength]byte
+	if err := decodeBase32("", "", []byte(expect), 0, back[:]); err != nil {
+		t.Errorf("failed to decodeBase32 %q: %v", expect, err)
+		return
+	}
+	checkBinary(t, "decodeBase32", in, back[:])
+}
+
+func TestTypeID_RoundTripping(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+
+	type testrow struct {
+		prefix string
+		bm     BinaryMode
+		output string
+	}
+	// The suffix is always in standard byte order, whatever the BinaryMode.
+	data := []testrow{
+		{"user", DenseFirst, "user_3qq6een2nm27m9da0rbr7ttrsn"},
+		{"user", StandardOnly, "user_3qq6een2nm27m9da0rbr7ttrsn"},
+		{"order_line", DenseOnly, "order_line_3qq6een2nm27m9da0rbr7ttrsn"},
+		{"", GUIDOnly, "3qq6een2nm27m9da0rbr7ttrsn"},
+	}
+	for _, row := range data {
+		t.Run(row.output+" "+row.bm.String(), func(t *testing.T) {
+			u := MustFromString(text)
+			u.SetPreferences(Preferences{Binary: row.bm})
+			id, err := MakeTypeID(row.prefix, u)
+			if err != nil {
+				t.Errorf("failed to MakeTypeID %q: %v", row.prefix, err)
+				return
+			}
+			checkString(t, "String", row.output, id.String())
+			checkText(t, "MarshalText", row.output, justBytes(id.MarshalText()))
+			checkText(t, "MarshalJSON", quoted(row.output), justBytes(id.MarshalJSON()))
+			checkValue(t, "Value", row.output, justValue(id.Value()))
+
+			var parsed TypeID
+			parsed.SetPreferences(Preferences{Binary: row.bm})
+			if err := parsed.FromString(row.output); err != nil {
+				t.Errorf("failed to FromString %q: %v", row.output, err)
+				return
+			}
+			checkString(t, "Prefix", row.prefix, parsed.Prefix())
+			checkEqual(t, "UUID", true, u, parsed.UUID())
+
+			parsed = TypeID{}
+			parsed.SetPreferences(Preferences{Binary: row.bm})
+			if err := json.Unmarshal([]byte(quoted(row.output)), &parsed); err != nil {
+				t.Errorf("failed to UnmarshalJSON %q: %v", row.output, err)
+				return
+			}
+			checkEqual(t, "UnmarshalJSON", true, u, parsed.UUID())
+
+			parsed = TypeID{}
+			parsed.SetPreferences(Preferences{Binary: row.bm})
+			if err := parsed.Scan([]byte(row.output)); err != nil {
+				t.Errorf("failed to Scan %q: %v", row.output, err)
+				return
+			}
+			checkEqual(t, "Scan", true, u, parsed.UUID())
+		})
+	}
+}
+
+func TestTypeID_Spec(t *testing.T) {
+	type testrow struct {
+		input  string
+		prefix string
+		text   string
+	}
+	data := []testrow{
+		// Example from the TypeID specification, a V7 UUID.
+		{"user_01h455vb4pex5vsknk084sn02q", "user", "01890a5d-ac96-774b-bcce-b302099a8057"},
+		{"0j6hb7h6nw9qqr28t5cy4tqkff", "", "12345678-9abc-4def-8123-456789abcdef"},
+		{"nil_00000000000000000000000000", "nil", "00000000-0000-0000-0000-000000000000"},
+	}
+	for _, row := range data {
+		t.Run(row.input, func(t *testing.T) {
+			id, err := ParseTypeID(row.input)
+			if err != nil {
+				t.Fatalf("failed to ParseTypeID: %v", err)
+			}
+			checkString(t, "Prefix", row.prefix, id.Prefix())
+			checkString(t, "UUID", row.text, id.UUID().CanonicalString())
+			checkString(t, "String", row.input, id.String())
+
+			made, err := MakeTypeID(row.prefix, MustFromString(row.text))
+			if err != nil {
+				t.Fatalf("failed to MakeTypeID: %v", err)
+			}
+			checkString(t, "MakeTypeID", row.input, made.String())
+		})
+	}
+}
+
+func TestTypeID_ScanNil(t *testing.T) {
+	id := MustNewTypeID("user")
+	if err := id.Scan(nil); err != nil {
+		t.Fatalf("failed to Scan nil: %v", err)
+	}
+	checkString(t, "Prefix", "user", id.Prefix())
+	if !id.UUID().IsNil() {
+		t.Errorf("wrong UUID after Scan nil: expected Nil, got %v", id.UUID())
+	}
+}
+
+func TestNewTypeID_Sorts(t *testing.T) {
+	var last string
+	for i := 0; i < 1000; i++ {
+		id := MustNewTypeID("user")
+		if version := id.UUID().Version(); version != V7 {
+			t.Fatalf("wrong version: expected V7, got %v", version)
+		}
+		str := id.String()
+		if str <= last {
+			t.Fatalf("%s generated after %s", str, last)
+		}
+		last = str
+	}
+}
+
+func TestTypeID_Failure(t *testing.T) {
+	valid := "user_3qq6een2nm27m9da0rbr7ttrsn"
+
+	if _, err := ParseTypeIDWithPrefix("user", valid); err != nil {
+		t.Errorf("failed to ParseTypeIDWithPrefix %q: %v", valid, err)
+	}
+	if _, err := ParseTypeIDWithPrefix("order", valid); err == nil {
+		t.Errorf("unexpected success at ParseTypeIDWithPrefix %q with mismatched prefix", valid)
+	} else if !errors.Is(err, ErrBadFormat) {
+		t.Errorf("wrong error for mismatched prefix: %v", err)
+	}
+	if _, err := ParseTypeIDWithPrefix("", valid); !errors.Is(err, ErrBadFormat) {
+		t.Errorf("wrong error at ParseTypeIDWithPrefix %q with empty prefix: %v", valid, err)
+	}
+	if _, err := ParseTypeIDWithPrefix("", "3qq6een2nm27m9da0rbr7ttrsn"); err != nil {
+		t.Errorf("failed to ParseTypeIDWithPrefix with empty prefix: %v", err)
+	}
+
+	type failrow struct {
+		input    string
+		kind     ErrorKind
+		position int
+	}
+	faildata := []failrow{
+		{"", KindTooShort, 0},
+		{"user_", KindTooShort, 5},
+		{"_3qq6een2nm27m9da0rbr7ttrsn", KindBadByte, 0},
+		{"User_3qq6een2nm27m9da0rbr7ttrsn", KindBadByte, 0},
+		{"user-3qq6een2nm27m9da0rbr7ttrsn", KindTrailingData, 26},
+		{"user__3qq6een2nm27m9da0rbr7ttrsn", KindBadByte, 4},
+		{"user_3qq6een2nm27m9da0rbr7ttrs", KindTooShort, 30},
+		{"user_3qq6een2nm27m9da0rbr7ttrsnn", KindTrailingData, 31},
+		{"user_3qq6een2nm27m9da0rbr7ttrsu", KindBadByte, 30},
+		{"user_8qq6een2nm27m9da0rbr7ttrsn", KindBadByte, 5},
+		{"user_3QQ6EEN2NM27M9DA0RBR7TTRSN", KindBadByte, 6},     // uppercase suffix
+		{"user_0hx25b8xxskkn9da0rbr7ttrsn", KindBadVersion, -1}, // dense byte order, V9
+	}
+	for _, row := range faildata {
+		_, err := ParseTypeID(row.input)
+		if err == nil {
+			t.Errorf("unexpected success at ParseTypeID %q", row.input)
+			continue
+		}
+		pe := err.(ParseError)
+		if pe.Kind != row.kind || pe.Position != row.position {
+			t.Errorf("wrong error for ParseTypeID %q: expected %v at %d, got %v at %d", row.input, row.kind, row.position, pe.Kind, pe.Position)
+		}
+		if !errors.Is(err, row.kind.Sentinel()) {
+			t.Errorf("errors.Is(%v, %v) is false", err, row.kind.Sentinel())
+		}
+	}
+
+	badPrefixes := []string{
+		"User",
+		"_user",
+		"user_",
+		"us3r",
+		"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijkl",
+	}
+	for _, prefix := range badPrefixes {
+		if _, err := NewTypeID(prefix); err == nil {
+			t.Errorf("unexpected success at NewTypeID %q", prefix)
+		} else if !errors.Is(err, ErrBadByte) {
+			t.Errorf("wrong error for NewTypeID %q: %v", prefix, err)
+		}
+	}
+}
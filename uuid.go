@@ -14,9 +14,11 @@ const ByteLength = 16
 // UUID holds a single Universally Unique Identifier.
 type UUID struct {
 	a [ByteLength]byte
-	b bits
+	b packedBits
 }
 
+var _ fmt.Stringer = Key{}
+
 var _ fmt.Stringer = UUID{}
 var _ fmt.Stringer = (*UUID)(nil)
 
@@ -42,12 +44,38 @@ var _ driver.Valuer = UUID{}
 var _ driver.Valuer = (*UUID)(nil)
 var _ sql.Scanner = (*UUID)(nil)
 
+// Key is a comparable form of a UUID, suitable for use as a map key.
+//
+// Two UUIDs that hold the same value but carry different Preferences are
+// unequal under the == operator, whereas their Keys are always equal.
+type Key [ByteLength]byte
+
+// UUID returns the UUID held by this Key, with default Preferences.
+func (key Key) UUID() UUID {
+	return UUID{a: key}
+}
+
+// String fulfills the "fmt".Stringer interface.
+// It produces a textual representation of the UUID held by this Key.
+func (key Key) String() string {
+	return key.UUID().String()
+}
+
 // Nil returns a Nil-valued UUID.
 func Nil() UUID {
 	var uuid UUID
 	return uuid
 }
 
+// Max returns the Max UUID, "ffffffff-ffff-ffff-ffff-ffffffffffff", in which
+// every bit is set.  RFC 9562 defines it for use as an upper bound, since it
+// sorts after every other UUID in every Order.
+func Max() UUID {
+	var uuid UUID
+	uuid.SetMax()
+	return uuid
+}
+
 // New returns a newly generated V1 UUID.
 func New() UUID {
 	var uuid UUID
@@ -55,6 +83,24 @@ func New() UUID {
 	return uuid
 }
 
+// NewSequential returns a newly generated V1 UUID which is guaranteed to sort
+// after every UUID previously generated by this process, in DenseOrder.
+// Stored with SQLServerOnly, such UUIDs make sequential inserts in SQL Server.
+func NewSequential() UUID {
+	var uuid UUID
+	uuid.SetNewSequential()
+	return uuid
+}
+
+// NewV7 returns a newly generated V7 UUID, which leads with a Unix timestamp
+// in milliseconds.  It is guaranteed to sort after every V7 UUID previously
+// generated by this process, in StandardOrder.
+func NewV7() UUID {
+	var uuid UUID
+	uuid.SetNewV7()
+	return uuid
+}
+
 // FromBytes attempts to parse a binary UUID representation.
 func FromBytes(in []byte) (UUID, error) {
 	var uuid UUID
@@ -75,37 +121,48 @@ func MustFromBytes(in []byte) UUID {
 // FromString attempts to parse a textual UUID representation.
 func FromString(in string) (UUID, error) {
 	var uuid UUID
-	err := unmarshalText("", "FromString", uuid.a[:], []byte(in))
+	err := uuid.unmarshalText("", "FromString", []byte(in))
 	return uuid, err
 }
 
 // MustFromString parses a textual UUID representation, or panics if it cannot.
 func MustFromString(in string) UUID {
 	var uuid UUID
-	err := unmarshalText("", "MustFromString", uuid.a[:], []byte(in))
+	err := uuid.unmarshalText("", "MustFromString", []byte(in))
 	if err != nil {
 		panic(err)
 	}
 	return uuid
 }
 
+// Detect attempts to parse a textual UUID representation, and reports the
+// TextMode whose format it was written in.  Bracketed input is reported as
+// Registry if its hex digits are all uppercase.  Empty input, which parses as
+// the Nil UUID, is reported as 0.
+func Detect(in string) (TextMode, error) {
+	var tmp [ByteLength]byte
+	return detectText("", "Detect", tmp[:], []byte(in), bits(0).defaulted())
+}
+
 // Equal returns true iff its two arguments hold the same UUID.
 func Equal(u1, u2 UUID) bool {
 	return u1.Equal(u2)
 }
 
 func (uuid UUID) getBits() bits {
-	return uuid.b.defaulted()
+	return uuid.b.unpack().defaulted()
 }
 
-// Preferences returns the preference knobs for this object.
+// Preferences returns the preference knobs for this object.  Every mode is
+// filled in, from the defaults if it was never set, so the result prints in
+// full, e.g. "TdDZ:III./R".
 func (uuid UUID) Preferences() Preferences {
-	return uuid.b.defaulted().expand()
+	return uuid.b.unpack().defaulted().expand()
 }
 
 // SetPreferences updates the preference knobs for this object.
 func (uuid *UUID) SetPreferences(pref Preferences) {
-	uuid.b = pref.collapse(uuid.getBits())
+	uuid.b = pref.collapse(uuid.getBits()).pack()
 }
 
 // SetNil updates this UUID to hold the Nil UUID.
@@ -113,22 +170,47 @@ func (uuid *UUID) SetNil() {
 	zeroBytes(uuid.a[:])
 }
 
+// SetMax updates this UUID to hold the Max UUID.
+func (uuid *UUID) SetMax() {
+	uuid.a = allOnes
+}
+
 // SetNew updates this UUID to hold a newly generated V1 UUID.
 func (uuid *UUID) SetNew() {
 	globalState().generate(uuid.a[:])
 }
 
+// SetNewSequential updates this UUID to hold a newly generated sequential V1 UUID.
+func (uuid *UUID) SetNewSequential() {
+	globalState().generateSequential(uuid.a[:])
+}
+
+// SetNewV7 updates this UUID to hold a newly generated V7 UUID.
+func (uuid *UUID) SetNewV7() {
+	gStateV7.generate(uuid.a[:])
+}
+
 // IsNil returns true iff this object holds the Nil UUID.
 func (uuid UUID) IsNil() bool {
 	var zero [ByteLength]byte
 	return uuid.a == zero
 }
 
+// IsMax returns true iff this object holds the Max UUID.
+func (uuid UUID) IsMax() bool {
+	return uuid.a == allOnes
+}
+
 // Equal returns true iff this object and the argument hold the same UUID.
 func (uuid UUID) Equal(other UUID) bool {
 	return uuid.a == other.a
 }
 
+// Key returns a comparable Key that ignores this UUID's Preferences.
+func (uuid UUID) Key() Key {
+	return Key(uuid.a)
+}
+
 // VersionAndVariant returns this UUID's Version and Variant.  This method is
 // slightly more efficient than calling Version() and Variant() separately.
 func (uuid UUID) VersionAndVariant() (version Version, variant Variant) {
@@ -156,6 +238,7 @@ func (uuid UUID) IsV1() bool {
 }
 
 // IsValid returns true iff this UUID has a valid Version and a valid Variant.
+// Like the Nil UUID, the Max UUID has neither, so it is not valid.
 func (uuid UUID) IsValid() bool {
 	version, variant := uuid.VersionAndVariant()
 	return version.IsValid() && variant.IsValid()
@@ -168,6 +251,13 @@ func (uuid UUID) StandardBytes() []byte {
 	return out[:]
 }
 
+// GUIDBytes returns the binary representation of this UUID in Microsoft GUID byte order.
+func (uuid UUID) GUIDBytes() []byte {
+	var out [ByteLength]byte
+	marshalBinaryGUID(out[:], uuid.a[:])
+	return out[:]
+}
+
 // DenseBytes returns the binary representation of this UUID in "dense" byte order.
 func (uuid UUID) DenseBytes() []byte {
 	var out [ByteLength]byte
@@ -214,6 +304,14 @@ func (uuid UUID) BracketedString() string {
 	return w.String()
 }
 
+// RegistryString returns the textual representation of this UUID in uppercase "{8-4-4-4-12}" format.
+func (uuid UUID) RegistryString() string {
+	w := makeSliceWriter(bufferLength)
+	defer w.release()
+	marshalTextRegistry(&w, uuid.a[:])
+	return w.String()
+}
+
 // URNString returns the textual representation of this UUID in "urn:uuid:8-4-4-4-12" format.
 func (uuid UUID) URNString() string {
 	w := makeSliceWriter(bufferLength)
@@ -235,7 +333,7 @@ func (uuid UUID) DenseString() string {
 func (uuid UUID) String() string {
 	w := makeSliceWriter(bufferLength)
 	defer w.release()
-	marshalText(&w, uuid.a[:], uuid.getBits())
+	marshalText(&w, uuid.a[:], uuid.getBits().forChannel(channelFmt))
 	return w.String()
 }
 
@@ -245,7 +343,7 @@ func (uuid UUID) GoString() string {
 	w := makeSliceWriter(bufferLength)
 	defer w.release()
 	w.WriteString(`UUID("`)
-	marshalText(&w, uuid.a[:], uuid.getBits())
+	marshalText(&w, uuid.a[:], uuid.getBits().forChannel(channelFmt))
 	w.WriteString(`")`)
 	return w.String()
 }
@@ -264,9 +362,7 @@ func (uuid UUID) MarshalText() ([]byte, error) {
 func (uuid UUID) MarshalJSON() ([]byte, error) {
 	w := makeSliceWriter(bufferLength)
 	defer w.release()
-	w.WriteByte('"')
-	marshalText(&w, uuid.a[:], uuid.getBits())
-	w.WriteByte('"')
+	marshalJSON(&w, uuid.a[:], uuid.getBits())
 	return w.CopyBytes(), nil
 }
 
@@ -279,7 +375,7 @@ func (uuid UUID) Format(s fmt.State, verb rune) {
 	if hasWidth && width < 0 {
 		panic(fmt.Errorf("width is negative: %d < 0", width))
 	}
-	d := formatStudy(verb, hasPlus, hasSharp, hasMinus, hasWidth, uint(width), uuid.getBits())
+	d := formatStudy(verb, hasPlus, hasSharp, hasMinus, hasWidth, uint(width), uuid.getBits(), uuid.IsNil())
 
 	w := makeSliceWriter(d.peakCount)
 	defer w.release()
@@ -294,14 +390,26 @@ func (uuid UUID) Value() (driver.Value, error) {
 	return value, nil
 }
 
+// SQLLiteral returns a SQL literal in the given Dialect that matches the
+// value which Value would have bound, e.g. `X'11e88ab4...'` or `'@EeiKtHe5...'`.
+func (uuid UUID) SQLLiteral(d Dialect) string {
+	x := uuid.getBits()
+	return sqlLiteral(valueImpl(uuid.a[:], x), x.forChannel(channelSQL), d)
+}
+
 // FromStandardBytes attempts to parse a binary UUID representation in RFC 4122 byte order.
 func (uuid *UUID) FromStandardBytes(in []byte) error {
-	return unmarshalBinaryStandard("UUID", "FromStandardBytes", uuid.a[:], in)
+	return unmarshalBinaryStandard("UUID", "FromStandardBytes", uuid.a[:], in, uuid.getBits())
+}
+
+// FromGUIDBytes attempts to parse a binary UUID representation in Microsoft GUID byte order.
+func (uuid *UUID) FromGUIDBytes(in []byte) error {
+	return unmarshalBinaryGUID("UUID", "FromGUIDBytes", uuid.a[:], in, uuid.getBits())
 }
 
 // FromDenseBytes attempts to parse a binary UUID representation in "dense" byte order.
 func (uuid *UUID) FromDenseBytes(in []byte) error {
-	return unmarshalBinaryDense("UUID", "FromDenseBytes", uuid.a[:], in)
+	return unmarshalBinaryDense("UUID", "FromDenseBytes", uuid.a[:], in, uuid.getBits())
 }
 
 // FromBytes attempts to parse a binary UUID representation.
@@ -324,12 +432,12 @@ func (uuid *UUID) UnmarshalBinary(in []byte) error {
 
 // FromString attempts to parse a textual UUID representation.
 func (uuid *UUID) FromString(in string) error {
-	return unmarshalText("UUID", "FromString", uuid.a[:], []byte(in))
+	return uuid.unmarshalText("UUID", "FromString", []byte(in))
 }
 
 // MustFromString parses a textual UUID representation, or panics if it cannot.
 func (uuid *UUID) MustFromString(in string) {
-	if err := unmarshalText("UUID", "MustFromString", uuid.a[:], []byte(in)); err != nil {
+	if err := uuid.unmarshalText("UUID", "MustFromString", []byte(in)); err != nil {
 		panic(err)
 	}
 }
@@ -337,7 +445,7 @@ func (uuid *UUID) MustFromString(in string) {
 // UnmarshalText fulfills the "encoding".TextUnmarshaler interface.
 // It attempts to parse a textual UUID representation.
 func (uuid *UUID) UnmarshalText(in []byte) error {
-	return unmarshalText("UUID", "UnmarshalText", uuid.a[:], in)
+	return uuid.unmarshalText("UUID", "UnmarshalText", in)
 }
 
 // UnmarshalJSON fulfills the "encoding/json".Unmarshaler interface.
@@ -346,27 +454,38 @@ func (uuid *UUID) UnmarshalJSON(in []byte) error {
 	var ptr *string
 	if len(in) != 0 {
 		if err := json.Unmarshal(in, &ptr); err != nil {
-			return makeParseError("UUID", "UnmarshalJSON", in, true).detailf("json.Unmarshal: %v", err)
+			return makeParseError("UUID", "UnmarshalJSON", in, true).at(-1, KindBadJSON, "", "").wrap(err).detailf("json.Unmarshal: %v", err)
 		}
 	}
 	var str string
 	if ptr != nil {
 		str = *ptr
 	}
-	return unmarshalText("UUID", "UnmarshalJSON", uuid.a[:], []byte(str))
+	return uuid.unmarshalText("UUID", "UnmarshalJSON", []byte(str))
 }
 
 // Scan fulfills the "database/sql".Scanner interface.
 // It attempts to interpret a SQL value as a UUID representation of some kind.
 func (uuid *UUID) Scan(value interface{}) error {
-	switch v := value.(type) {
-	case nil:
-		zeroBytes(uuid.a[:])
-		return nil
-	case []byte:
-		return scanImpl("UUID", "Scan", uuid.a[:], v, false, uuid.getBits())
-	case string:
-		return scanImpl("UUID", "Scan", uuid.a[:], []byte(v), true, uuid.getBits())
+	tm, err := scanValue("UUID", "Scan", uuid.a[:], value, uuid.getBits())
+	if err == nil {
+		uuid.echo(tm)
+	}
+	return err
+}
+
+func (uuid *UUID) unmarshalText(typeName, methodName string, in []byte) error {
+	tm, err := detectText(typeName, methodName, uuid.a[:], in, uuid.getBits())
+	if err == nil {
+		uuid.echo(tm)
+	}
+	return err
+}
+
+// echo sets the Text mode to the format that was just parsed, if this
+// UUID's Echo mode asks for it.
+func (uuid *UUID) echo(tm TextMode) {
+	if x, changed := echoBits(uuid.getBits(), tm); changed {
+		uuid.b = x.pack()
 	}
-	return makeTypeError("UUID", "Scan", value, nil, []byte(nil), "")
 }
@@ -2,6 +2,7 @@ package uuid
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -269,7 +270,7 @@ func TestUUID_BasicOps(t *testing.T) {
 	u3 = UUID{}
 	u4 = UUID{}
 
-	u1.SetPreferences(Preferences{Binary, StandardFirst, Bracketed})
+	u1.SetPreferences(Preferences{Value: Binary, Binary: StandardFirst, Text: Bracketed})
 	u3.SetPreferences(u1.Preferences())
 
 	u0.MustFromString(text)
@@ -320,6 +321,7 @@ func TestUUID_FromString(t *testing.T) {
 		input   string
 		output  []byte
 		failure bool
+		pref    Preferences
 	}
 	data := []testrow{
 		{
@@ -434,8 +436,10 @@ func TestUUID_FromString(t *testing.T) {
 		},
 
 		{
+			// Every base-64 digit class, decoding to V0 with VariantNCS.
 			input:  "@AZaz09+/-_AAAAAAAAAAAA",
 			output: nil,
+			pref:   Preferences{Validate: AcceptAny},
 		},
 		{
 			input:  "@EeiKtHe5nOqWqBheD61jNQ",
@@ -504,6 +508,7 @@ func TestUUID_FromString(t *testing.T) {
 			var expected, parsed UUID
 			var err error
 
+			parsed.SetPreferences(row.pref)
 			err = parsed.FromString(row.input)
 			if err != nil {
 				if !row.failure {
@@ -577,6 +582,15 @@ func TestUUID_UnmarshalBinary(t *testing.T) {
 		0x0f, 0xad, 0x63, 0x35,
 	}
 
+	// A V4 which would read as a V8 in dense order.
+	v4Text := "81855ad8-681d-4d86-91e9-1e00167939cb"
+	v4Bytes := []byte{
+		0x81, 0x85, 0x5a, 0xd8,
+		0x68, 0x1d, 0x4d, 0x86,
+		0x91, 0xe9, 0x1e, 0x00,
+		0x16, 0x79, 0x39, 0xcb,
+	}
+
 	type testrow struct {
 		input   []byte
 		order   BinaryMode
@@ -595,7 +609,7 @@ func TestUUID_UnmarshalBinary(t *testing.T) {
 		{allZeroes[:], DenseFirst, true, zero},
 
 		{standardBytes, StandardOnly, true, text},
-		{standardBytes, DenseOnly, false, ""},
+		{standardBytes, DenseOnly, true, "8ab411e8-9cea-77b9-96a8-185e0fad6335"}, // a V7, valid since RFC 9562
 		{standardBytes, StandardFirst, true, text},
 		{standardBytes, DenseFirst, true, text},
 
@@ -613,6 +627,9 @@ func TestUUID_UnmarshalBinary(t *testing.T) {
 		{ambiguousBytes1, StandardFirst, true, ambiguousText1A},
 		{ambiguousBytes1, DenseOnly, true, ambiguousText1B},
 		{ambiguousBytes1, DenseFirst, true, ambiguousText1B},
+
+		{v4Bytes, StandardFirst, true, v4Text},
+		{v4Bytes, DenseFirst, true, v4Text},
 	}
 	for _, row := range data {
 		pref := bitsDefault.expand()
@@ -638,6 +655,165 @@ func TestUUID_UnmarshalBinary(t *testing.T) {
 			}
 		})
 	}
+
+	// Read as dense, standardBytes is a V7, which RFC 4122 alone rejects.
+	p := Parser{Versions: VersionsOf(V1, V2, V3, V4, V5), Preferences: Preferences{Binary: DenseOnly}}
+	if u, err := p.FromBytes(standardBytes); err == nil {
+		t.Errorf("unexpected success at FromBytes %x under RFC 4122 versions: %s", standardBytes, u.CanonicalString())
+	}
+}
+
+func TestUUID_MySQLSwapFlag(t *testing.T) {
+	// Example from the MySQL 8 manual:
+	//   HEX(UUID_TO_BIN('6ccd780c-baba-1026-9564-5b8c656024db', 1))
+	//   -> '1026BABA6CCD780C95645B8C656024DB'
+	text := "6ccd780c-baba-1026-9564-5b8c656024db"
+	swapped := []byte{
+		0x10, 0x26, 0xba, 0xba,
+		0x6c, 0xcd, 0x78, 0x0c,
+		0x95, 0x64, 0x5b, 0x8c,
+		0x65, 0x60, 0x24, 0xdb,
+	}
+
+	for _, bm := range []BinaryMode{MySQLOnly, MySQLFirst} {
+		t.Run(bm.String(), func(t *testing.T) {
+			var u UUID
+			u.SetPreferences(Preferences{Value: Binary, Binary: bm})
+			if err := u.UnmarshalBinary(swapped); err != nil {
+				t.Errorf("failed to UnmarshalBinary %s: %v", formatBytes(swapped), err)
+				return
+			}
+			checkString(t, "UnmarshalBinary", text, u.CanonicalString())
+			checkBinary(t, "MarshalBinary", swapped, justBytes(u.MarshalBinary()))
+			checkValue(t, "Value", swapped, justValue(u.Value()))
+		})
+	}
+}
+
+func TestUUID_GUID(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	registry := "{77B99CEA-8AB4-11E8-96A8-185E0FAD6335}"
+	guidBytes := []byte{
+		0xea, 0x9c, 0xb9, 0x77,
+		0xb4, 0x8a, 0xe8, 0x11,
+		0x96, 0xa8, 0x18, 0x5e,
+		0x0f, 0xad, 0x63, 0x35,
+	}
+
+	u := MustFromString(text)
+	u.SetPreferences(Preferences{Value: Binary, Binary: GUIDOnly, Text: Registry})
+	checkPrefs(t, "SetPreferences", Binary, GUIDOnly, Registry, u)
+	checkBinary(t, "GUIDBytes", guidBytes, u.GUIDBytes())
+	checkBinary(t, "MarshalBinary", guidBytes, justBytes(u.MarshalBinary()))
+	checkValue(t, "Value", guidBytes, justValue(u.Value()))
+	checkString(t, "RegistryString", registry, u.RegistryString())
+	checkString(t, "String", registry, u.String())
+	checkString(t, "Format %v", registry, fmt.Sprintf("%v", u))
+	checkString(t, "Format %q", quoted(registry), fmt.Sprintf("%q", u))
+	checkMarshalJSON(t, "MarshalJSON", quoted(registry), u)
+
+	var parsed UUID
+	if err := parsed.FromString(registry); err != nil {
+		t.Errorf("failed to FromString %q: %v", registry, err)
+	}
+	checkEqual(t, "FromString", true, u, parsed)
+
+	parsed = UUID{}
+	if err := parsed.FromGUIDBytes(guidBytes); err != nil {
+		t.Errorf("failed to FromGUIDBytes: %v", err)
+	}
+	checkEqual(t, "FromGUIDBytes", true, u, parsed)
+
+	parsed = UUID{}
+	parsed.SetPreferences(Preferences{Binary: GUIDOnly})
+	if err := parsed.Scan(guidBytes); err != nil {
+		t.Errorf("failed to Scan: %v", err)
+	}
+	checkEqual(t, "Scan", true, u, parsed)
+
+	// Variant Microsoft: [... e8 96 a8 ...] -> [... e8 c6 a8 ...]
+	msStandard := []byte{0x77, 0xb9, 0x9c, 0xea, 0x8a, 0xb4, 0x11, 0xe8, 0xc6, 0xa8, 0x18, 0x5e, 0x0f, 0xad, 0x63, 0x35}
+	msGUID := []byte{0xea, 0x9c, 0xb9, 0x77, 0xb4, 0x8a, 0xe8, 0x11, 0xc6, 0xa8, 0x18, 0x5e, 0x0f, 0xad, 0x63, 0x35}
+	msText := "77b99cea-8ab4-11e8-c6a8-185e0fad6335"
+
+	type testrow struct {
+		input   []byte
+		bm      BinaryMode
+		success bool
+	}
+	data := []testrow{
+		{msStandard, StandardOnly, false},
+		{msStandard, StandardFirst, true},
+		{msStandard, DenseFirst, true},
+		{msGUID, GUIDOnly, true},
+	}
+	for _, row := range data {
+		name := fmt.Sprintf("%x-%s", row.input, row.bm)
+		t.Run(name, func(t *testing.T) {
+			var u UUID
+			u.SetPreferences(Preferences{Binary: row.bm})
+			err := u.UnmarshalBinary(row.input)
+			switch {
+			case err != nil && row.success:
+				t.Errorf("failed to UnmarshalBinary: %v", err)
+			case err == nil && !row.success:
+				t.Errorf("unexpected success at UnmarshalBinary: %s", u.CanonicalString())
+			case err == nil:
+				checkString(t, "UnmarshalBinary", msText, u.CanonicalString())
+				checkVariant(t, "UnmarshalBinary", VariantMicrosoft, u)
+			}
+		})
+	}
+}
+
+func TestUUID_SQLServer(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	sqlBytes := []byte{
+		0x0f, 0xad, 0x63, 0x35,
+		0x18, 0x5e, 0x96, 0xa8,
+		0x9c, 0xea, 0x11, 0xe8,
+		0x8a, 0xb4, 0x77, 0xb9,
+	}
+
+	u := MustFromString(text)
+	u.SetPreferences(Preferences{Value: Binary, Binary: SQLServerOnly, Text: Canonical})
+	checkPrefs(t, "SetPreferences", Binary, SQLServerOnly, Canonical, u)
+	checkBinary(t, "MarshalBinary", sqlBytes, justBytes(u.MarshalBinary()))
+	checkValue(t, "Value", sqlBytes, justValue(u.Value()))
+
+	var parsed UUID
+	parsed.SetPreferences(Preferences{Binary: SQLServerOnly})
+	if err := parsed.Scan(sqlBytes); err != nil {
+		t.Errorf("failed to Scan: %v", err)
+	}
+	checkEqual(t, "Scan", true, u, parsed)
+
+	parsed = UUID{}
+	parsed.SetPreferences(Preferences{Binary: SQLServerOnly})
+	standardBytes := []byte{0x77, 0xb9, 0x9c, 0xea, 0x8a, 0xb4, 0x11, 0xe8, 0x96, 0xa8, 0x18, 0x5e, 0x0f, 0xad, 0x63, 0x35}
+	if err := parsed.UnmarshalBinary(standardBytes); err == nil {
+		t.Errorf("unexpected success at UnmarshalBinary: %s", parsed.CanonicalString())
+	}
+
+	// SQL Server reads the stored bytes as a GUID, then compares in
+	// SQLServerOrder.  Generate enough UUIDs within a single tick to wrap the
+	// clock sequence, and check that the stored values never go backward.
+	pref := Preferences{Value: Binary, Binary: SQLServerOnly}
+	var prev, prevStored UUID
+	for i := 0; i < 20000; i++ {
+		u := pref.NewSequential()
+		var stored UUID
+		importGUID(stored.a[:], justBytes(u.MarshalBinary()))
+		if i > 0 {
+			if !DenseOrder.Less(prev, u) {
+				t.Fatalf("NewSequential %d: %s does not sort after %s in DenseOrder", i, u, prev)
+			}
+			if !SQLServerOrder.Less(prevStored, stored) {
+				t.Fatalf("NewSequential %d: %s does not sort after %s in SQLServerOrder", i, stored, prevStored)
+			}
+		}
+		prev, prevStored = u, stored
+	}
 }
 
 func TestUUID_UnmarshalBinary_Failure(t *testing.T) {
@@ -654,9 +830,9 @@ func TestUUID_UnmarshalBinary_Failure(t *testing.T) {
 		// [... b4 11 e8 ...] -> [... b4 01 e8 ...]
 		{0x77, 0xb9, 0x9c, 0xea, 0x8a, 0xb4, 0x01, 0xe8, 0x96, 0xa8, 0x18, 0x5e, 0x0f, 0xad, 0x63, 0x35},
 
-		// Version 6
-		// [... b4 11 e8 ...] -> [... b4 61 e8 ...]
-		{0x77, 0xb9, 0x9c, 0xea, 0x8a, 0xb4, 0x61, 0xe8, 0x96, 0xa8, 0x18, 0x5e, 0x0f, 0xad, 0x63, 0x35},
+		// Version 9
+		// [... b4 11 e8 ...] -> [... b4 91 e8 ...]
+		{0x77, 0xb9, 0x9c, 0xea, 0x8a, 0xb4, 0x91, 0xe8, 0x96, 0xa8, 0x18, 0x5e, 0x0f, 0xad, 0x63, 0x35},
 
 		// Variant NCS
 		// [... e8 96 a8 ...] -> [... e8 76 a8 ...]
@@ -674,7 +850,7 @@ func TestUUID_UnmarshalBinary_Failure(t *testing.T) {
 		name := fmt.Sprintf("%x", item)
 		t.Run(name, func(t *testing.T) {
 			var uuid UUID
-			uuid.SetPreferences(Preferences{Binary, StandardOnly, Canonical})
+			uuid.SetPreferences(Preferences{Value: Binary, Binary: StandardOnly, Text: Canonical})
 			if err := uuid.UnmarshalBinary(item); err == nil {
 				x := formatBytes(item)
 				t.Errorf("unexpected success at UnmarshalBinary %s: %s", x, uuid.CanonicalString())
@@ -702,11 +878,11 @@ func TestUUID_JSON(t *testing.T) {
 	zeroDenseJSON := `"` + zeroDense + `"`
 
 	var u UUID
-	u.SetPreferences(Preferences{0, 0, Canonical})
+	u.SetPreferences(Preferences{Text: Canonical})
 	checkMarshalJSON(t, "canon nil", zeroJSON, u)
 	u.MustFromString(text)
 	checkMarshalJSON(t, "canon real", textJSON, u)
-	u.SetPreferences(Preferences{0, 0, Dense})
+	u.SetPreferences(Preferences{Text: Dense})
 	checkMarshalJSON(t, "dense real", textDenseJSON, u)
 	u.SetNil()
 	checkMarshalJSON(t, "dense nil", zeroDenseJSON, u)
@@ -720,6 +896,60 @@ func TestUUID_JSON(t *testing.T) {
 	checkUnmarshalJSON(t, "JSON omit", allZeroes[:], ``)
 }
 
+func TestUUID_NilAs(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	zeroDense := "@AAAAAAAAAAAAAAAAAAAAAA"
+
+	type testrow struct {
+		nm     NilMode
+		vm     ValueMode
+		str    string
+		json   string
+		value  interface{}
+		format string
+	}
+	data := []testrow{
+		{NilZero, Text, zeroDense, quoted(zeroDense), zeroDense, zeroDense},
+		{NilZero, Binary, zeroDense, quoted(zeroDense), allZeroes[:], zeroDense},
+		{NilNull, Text, zeroDense, `null`, nil, zeroDense},
+		{NilNull, Binary, zeroDense, `null`, nil, zeroDense},
+		{NilEmpty, Text, ``, `""`, ``, ``},
+		{NilEmpty, Binary, ``, `""`, []byte{}, ``},
+		{NilJSONNull, Text, zeroDense, `null`, zeroDense, zeroDense},
+		{NilJSONNull, Binary, zeroDense, `null`, allZeroes[:], zeroDense},
+	}
+	for _, row := range data {
+		pref := Preferences{Value: row.vm, Binary: DenseOnly, Text: Dense, NilAs: row.nm}
+		t.Run(pref.String(), func(t *testing.T) {
+			var u UUID
+			u.SetPreferences(pref)
+			checkPrefs(t, "SetPreferences", row.vm, DenseOnly, Dense, u)
+			if nm := u.Preferences().NilAs; nm != row.nm {
+				t.Errorf("wrong Preferences.NilAs: expected %q, got %q", row.nm, nm)
+			}
+
+			checkString(t, "String", row.str, u.String())
+			checkText(t, "MarshalText", row.str, justBytes(u.MarshalText()))
+			checkString(t, "Format %v", row.format, fmt.Sprintf("%v", u))
+			checkString(t, "Format %40v", leftPad(40, row.format), fmt.Sprintf("%40v", u))
+			checkString(t, "Format %-40v", rightPad(40, row.format), fmt.Sprintf("%-40v", u))
+			checkString(t, "DenseString", zeroDense, u.DenseString())
+			checkMarshalJSON(t, "MarshalJSON", row.json, u)
+			if row.value == nil {
+				if v := justValue(u.Value()); v != nil {
+					t.Errorf("flubbed Value: expected nil, got %#v", v)
+				}
+			} else {
+				checkValue(t, "Value", row.value, justValue(u.Value()))
+			}
+
+			u.MustFromString(text)
+			checkString(t, "String of non-Nil", "@EeiKtHe5nOqWqBheD61jNQ", u.String())
+			checkMarshalJSON(t, "MarshalJSON of non-Nil", `"@EeiKtHe5nOqWqBheD61jNQ"`, u)
+		})
+	}
+}
+
 func TestUUID_Scan(t *testing.T) {
 	zero := "00000000-0000-0000-0000-000000000000"
 	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
@@ -751,6 +981,15 @@ func TestUUID_Scan(t *testing.T) {
 		{"string-empty", "", true, zero},
 		{"string-zero", zero, true, zero},
 		{"string-text", text, true, text},
+		{"string-std", string(standardBytes), true, text},
+		{"string-dense", string(denseBytes), true, text},
+		{"array-std", [ByteLength]byte(MustFromString(text).a), true, text},
+		{"uuid", MustFromString(text), true, text},
+		{"uuid-ptr", &UUID{a: MustFromString(text).a}, true, text},
+		{"uuid-ptr-nil", (*UUID)(nil), true, zero},
+		{"stringer", testStringer(text), true, text},
+		{"stringer-bad", testStringer("bogus"), false, ""},
+		{"int", 42, false, ""},
 	}
 	for _, row := range data {
 		pref := bitsDefault.expand()
@@ -777,6 +1016,385 @@ func TestUUID_Scan(t *testing.T) {
 	}
 }
 
+type testStringer string
+
+func (s testStringer) String() string {
+	return string(s)
+}
+
+func TestUUID_Channels(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	bracketed := "{" + text + "}"
+	dense := "@EeiKtHe5nOqWqBheD61jNQ"
+
+	u := MustFromString(text)
+	u.SetPreferences(Preferences{Value: Text, Text: HashLike, JSON: Canonical, SQL: Dense, Fmt: Bracketed})
+	pref := u.Preferences()
+	if pref.Text != HashLike || pref.JSON != Canonical || pref.SQL != Dense || pref.Fmt != Bracketed {
+		t.Errorf("wrong Preferences: got %+v", pref)
+	}
+	checkString(t, "Preferences.String", "TdHZ:CDB./R", pref.String())
+	checkText(t, "MarshalText", "77b99cea8ab411e896a8185e0fad6335", justBytes(u.MarshalText()))
+	checkMarshalJSON(t, "MarshalJSON", quoted(text), u)
+	checkValue(t, "Value", dense, justValue(u.Value()))
+	checkString(t, "SQLLiteral", `'`+dense+`'`, u.SQLLiteral(Postgres))
+	checkString(t, "String", bracketed, u.String())
+	checkString(t, "Format %v", bracketed, fmt.Sprintf("%v", u))
+	checkString(t, "Format %q", quoted(bracketed), fmt.Sprintf("%q", u))
+	checkValue(t, "AsText", text, justValue(AsText(u, Canonical).Value()))
+	checkValue(t, "List.Value", "{"+dense+"}", justValue(ListOf(u).Value()))
+	checkValue(t, "Array.Value", "{"+dense+"}", justValue(Array{{UUID: u, Valid: true}}.Value()))
+
+	// Zero leaves overrides unchanged, Inherit resets them.
+	u.SetPreferences(Preferences{Text: Canonical, Fmt: URN})
+	checkMarshalJSON(t, "MarshalJSON after SetPreferences", quoted(text), u)
+	checkString(t, "String after SetPreferences", "urn:uuid:"+text, u.String())
+	u.SetPreferences(Preferences{JSON: Inherit, SQL: Inherit, Fmt: Inherit})
+	checkString(t, "Preferences.String after Inherit", "TdCZ:III./R", u.Preferences().String())
+	checkValue(t, "Value after Inherit", text, justValue(u.Value()))
+	checkString(t, "String after Inherit", text, u.String())
+
+	var zero UUID
+	if pref := zero.Preferences(); pref.JSON != Inherit || pref.SQL != Inherit || pref.Fmt != Inherit {
+		t.Errorf("wrong default overrides: got %+v", pref)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("SetPreferences with Text: Inherit did not panic")
+			}
+		}()
+		u.SetPreferences(Preferences{Text: Inherit})
+	}()
+}
+
+func TestDetect(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	upper := strings.ToUpper(text)
+	type testrow struct {
+		input    string
+		expected TextMode
+	}
+	data := []testrow{
+		{"", 0},
+		{"@EeiKtHe5nOqWqBheD61jNQ", Dense},
+		{text, Canonical},
+		{upper, Canonical},
+		{dehyphened(text), HashLike},
+		{bracketed(text), Bracketed},
+		{bracketed(upper), Registry},
+		{bracketed(dehyphened(upper)), Registry},
+		{urned(text), URN},
+		{urned(bracketed(upper)), URN},
+		{"  " + text, Canonical},
+	}
+	for _, row := range data {
+		t.Run(row.input, func(t *testing.T) {
+			tm, err := Detect(row.input)
+			if err != nil {
+				t.Errorf("failed to Detect: %v", err)
+			} else if tm != row.expected {
+				t.Errorf("wrong TextMode: expected %v, got %v", row.expected, tm)
+			}
+		})
+	}
+
+	_, err := Detect("bogus")
+	if msg := errString(err); !strings.HasPrefix(msg, `uuid.Detect: failed to parse "bogus"`) {
+		t.Errorf("wrong error: got %q", msg)
+	}
+}
+
+func TestUUID_Echo(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	upper := strings.ToUpper(text)
+	inputs := []string{
+		"@EeiKtHe5nOqWqBheD61jNQ",
+		text,
+		dehyphened(text),
+		bracketed(text),
+		bracketed(upper),
+		urned(text),
+	}
+	echo := Preferences{Value: Text, Text: Canonical, Echo: EchoOn}
+	checkString(t, "Preferences.String", "T-C-+", echo.String())
+	for _, input := range inputs {
+		u, err := echo.FromString(input)
+		if err != nil {
+			t.Errorf("failed to FromString(%q): %v", input, err)
+			continue
+		}
+		checkString(t, "String after FromString", input, u.String())
+		checkMarshalJSON(t, "MarshalJSON after FromString", quoted(input), u)
+
+		var v UUID
+		v.SetPreferences(echo)
+		if err := v.Scan(input); err != nil {
+			t.Errorf("failed to Scan(%q): %v", input, err)
+			continue
+		}
+		checkValue(t, "Value after Scan", input, justValue(v.Value()))
+	}
+
+	// Empty input leaves the TextMode unchanged.
+	u := echo.MustFromString(dehyphened(text))
+	u.MustFromString("")
+	if tm := u.Preferences().Text; tm != HashLike {
+		t.Errorf("wrong TextMode after empty input: expected %v, got %v", HashLike, tm)
+	}
+
+	// Without Echo, parsing leaves the TextMode alone.
+	u = Preferences{Text: Canonical}.MustFromString(urned(text))
+	checkString(t, "String without Echo", text, u.String())
+
+	var nu NullUUID
+	nu.SetPreferences(echo)
+	if err := nu.UnmarshalJSON([]byte(quoted(bracketed(upper)))); err != nil {
+		t.Errorf("failed to NullUUID.UnmarshalJSON: %v", err)
+	}
+	checkString(t, "NullUUID.String", bracketed(upper), nu.UUID.String())
+
+	saved := DefaultPreferences()
+	defer SetDefaultPreferences(saved)
+	SetDefaultPreferences(Preferences{Echo: EchoOn})
+	id := MustParseID[testUserKind](urned(text))
+	checkString(t, "ID.String", urned(text), id.String())
+}
+
+func TestUUID_Validate(t *testing.T) {
+	v1 := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+	v4 := "6ba7b810-9dad-41d1-80b4-00c04fd430c8"
+	v0 := "77b99cea-8ab4-01e8-96a8-185e0fad6335"
+	v6 := "1ec9414c-232a-6b00-b3c8-9e6bdeced846"
+	v7 := "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"
+	v8 := "2489e9ad-2ee2-8e00-8ec9-32d5f69181c0"
+	bad := "77b99cea-8ab4-11e8-f6a8-185e0fad6335"
+
+	type testrow struct {
+		pref    Preferences
+		input   string
+		errText string
+	}
+	data := []testrow{
+		{Preferences{}, v1, ""},
+		{Preferences{}, v6, ""},
+		{Preferences{}, v7, ""},
+		{Preferences{}, v8, ""},
+		{Preferences{}, v0, "expected version V1-V8, got V0"},
+		{Preferences{}, bad, "expected VariantRFC4122, got VariantFuture"},
+		{Preferences{Validate: AcceptAny}, v0, ""},
+		{Preferences{Validate: AcceptAny}, bad, ""},
+		{Preferences{Validate: AcceptAny}, v4, ""},
+		{Preferences{Validate: AcceptAny}, "", ""},
+	}
+	for _, row := range data {
+		t.Run(row.pref.String()+" "+row.input, func(t *testing.T) {
+			var binary []byte
+			if row.input != "" {
+				var tmp UUID
+				tmp.SetPreferences(Preferences{Validate: AcceptAny})
+				tmp.MustFromString(row.input)
+				binary = tmp.a[:]
+			}
+
+			check := func(opName string, err error) {
+				t.Helper()
+				switch {
+				case err != nil && row.errText == "":
+					t.Errorf("unexpected failure at %s: %v", opName, err)
+				case err == nil && row.errText != "":
+					t.Errorf("unexpected success at %s", opName)
+				case err != nil:
+					if detail := err.(ParseError).Detail; detail != row.errText {
+						t.Errorf("wrong error at %s: expected %q, got %q", opName, row.errText, detail)
+					}
+				}
+			}
+
+			_, err := row.pref.FromString(row.input)
+			check("FromString", err)
+			_, err = Preferences{Binary: StandardOnly, Validate: row.pref.Validate}.FromBytes(binary)
+			check("FromBytes", err)
+
+			u := row.pref.Nil()
+			check("UnmarshalJSON", u.UnmarshalJSON([]byte(quoted(row.input))))
+			u = row.pref.Nil()
+			check("Scan", u.Scan(row.input))
+			u = Preferences{Binary: StandardOnly, Validate: row.pref.Validate}.Nil()
+			check("Scan binary", u.Scan(binary))
+		})
+	}
+
+	// A failed parse leaves the UUID unchanged.
+	u := MustFromString(v1)
+	if err := u.FromString(v0); err == nil {
+		t.Errorf("unexpected success at FromString %q", v0)
+	}
+	checkString(t, "String after failure", v1, u.CanonicalString())
+
+	saved := DefaultPreferences()
+	defer SetDefaultPreferences(saved)
+	SetDefaultPreferences(Preferences{Validate: AcceptAny})
+	if _, err := FromString(v0); err != nil {
+		t.Errorf("failed to FromString %q with AcceptAny default: %v", v0, err)
+	}
+}
+
+func TestUUID_ValidateRoundTrip(t *testing.T) {
+	// Whatever binary parsing accepts, text parsing must accept too.
+	microsoft := []byte{
+		0x77, 0xb9, 0x9c, 0xea,
+		0x8a, 0xb4, 0x41, 0xe8,
+		0xc6, 0xa8, 0x18, 0x5e,
+		0x0f, 0xad, 0x63, 0x35,
+	}
+	modes := []BinaryMode{StandardOnly, StandardFirst, DenseOnly, DenseFirst, GUIDOnly, SQLServerOnly}
+	for _, bm := range modes {
+		pref := Preferences{Binary: bm}
+		t.Run(pref.String(), func(t *testing.T) {
+			u, binErr := pref.FromBytes(microsoft)
+			if binErr != nil {
+				u = Preferences{Binary: bm, Validate: AcceptAny}.MustFromBytes(microsoft)
+			}
+			texts := []string{u.String(), u.CanonicalString(), u.RegistryString(), u.URNString()}
+			for _, text := range texts {
+				parsed, err := pref.FromString(text)
+				switch {
+				case binErr == nil && err != nil:
+					t.Errorf("FromBytes accepted %v, but FromString %q failed: %v", u, text, err)
+				case binErr != nil && err == nil:
+					t.Errorf("FromBytes rejected %v, but FromString %q succeeded", u, text)
+				case err == nil:
+					checkEqual(t, "FromString", true, u, parsed)
+				}
+			}
+		})
+	}
+
+	// FromStandardBytes and FromDenseBytes follow the same rule.
+	for _, bm := range modes {
+		pref := Preferences{Binary: bm}
+		for _, dense := range []bool{false, true} {
+			var u UUID
+			u.SetPreferences(pref)
+			binErr, opName := u.FromStandardBytes(microsoft), "FromStandardBytes"
+			accepted := Preferences{Binary: StandardOnly, Validate: AcceptAny}.MustFromBytes(microsoft)
+			if dense {
+				binErr, opName = u.FromDenseBytes(microsoft), "FromDenseBytes"
+				accepted = Preferences{Binary: DenseOnly, Validate: AcceptAny}.MustFromBytes(microsoft)
+			}
+			_, err := pref.FromString(accepted.CanonicalString())
+			if (binErr == nil) != (err == nil) {
+				t.Errorf("%s with %v: %v, but FromString %q: %v", opName, pref, binErr, accepted.CanonicalString(), err)
+			}
+		}
+	}
+
+	// With the default Preferences, a Microsoft-variant value read from
+	// binary can be written out and read back in.
+	u, err := FromBytes(microsoft)
+	if err != nil {
+		t.Fatalf("failed to FromBytes: %v", err)
+	}
+	if _, err := FromString(u.String()); err != nil {
+		t.Errorf("failed to FromString %q: %v", u.String(), err)
+	}
+}
+
+func TestUUID_ScanValidates(t *testing.T) {
+	v0 := Preferences{Validate: AcceptAny}.MustFromString("77b99cea-8ab4-01e8-96a8-185e0fad6335")
+	inputs := []interface{}{v0, &v0}
+	for _, input := range inputs {
+		var u UUID
+		if err := u.Scan(input); !errors.Is(err, ErrBadVersion) {
+			t.Errorf("wrong error at Scan %T: %v", input, err)
+		}
+		u.SetPreferences(Preferences{Validate: AcceptAny})
+		if err := u.Scan(input); err != nil {
+			t.Errorf("failed to Scan %T with AcceptAny: %v", input, err)
+		} else {
+			checkEqual(t, "Scan", true, v0, u)
+		}
+	}
+}
+
+func TestUUID_Max(t *testing.T) {
+	text := "ffffffff-ffff-ffff-ffff-ffffffffffff"
+	ones := bytes.Repeat([]byte{0xff}, ByteLength)
+
+	u := Max()
+	if !u.IsMax() || u.IsNil() || u.IsValid() {
+		t.Errorf("wrong IsMax/IsNil/IsValid for %v", u)
+	}
+	if version, variant := u.VersionAndVariant(); version != 0 || variant != 0 {
+		t.Errorf("wrong VersionAndVariant: got %v, %v", version, variant)
+	}
+	checkString(t, "CanonicalString", text, u.CanonicalString())
+	checkString(t, "DenseString", "@/////////////////////w", u.DenseString())
+	checkValue(t, "Value", "@/////////////////////w", justValue(u.Value()))
+	checkMarshalJSON(t, "MarshalJSON", quoted(text), Preferences{Text: Canonical}.Max())
+
+	var v UUID
+	v.SetNil()
+	v.SetMax()
+	checkEqual(t, "SetMax", true, u, v)
+	if MustFromString(text).IsMax() == false {
+		t.Errorf("MustFromString did not yield Max")
+	}
+	if !MustFromString("@/////////////////////w").IsMax() {
+		t.Errorf("MustFromString of dense did not yield Max")
+	}
+
+	binaryModes := []BinaryMode{StandardOnly, StandardFirst, DenseOnly, DenseFirst, GUIDOnly, SQLServerOnly}
+	for _, bm := range binaryModes {
+		pref := Preferences{Value: Binary, Binary: bm}
+		parsed, err := pref.FromBytes(ones)
+		if err != nil {
+			t.Errorf("failed to FromBytes with %v: %v", bm, err)
+			continue
+		}
+		if !parsed.IsMax() {
+			t.Errorf("wrong FromBytes with %v: got %v", bm, parsed)
+		}
+		checkValue(t, "Value with "+bm.String(), ones, justValue(pref.Max().Value()))
+	}
+
+	for _, order := range []Order{StandardOrder, DenseOrder, SQLServerOrder} {
+		if order.Compare(New(), u) >= 0 {
+			t.Errorf("Max does not sort last in %v", order)
+		}
+	}
+}
+
+func TestUUID_Key(t *testing.T) {
+	text := "77b99cea-8ab4-11e8-96a8-185e0fad6335"
+
+	u0 := MustFromString(text)
+	u1 := MustFromString(text)
+	u1.SetPreferences(Preferences{Value: Binary, Binary: StandardOnly, Text: Canonical})
+	if u0 == u1 {
+		t.Errorf("expected UUIDs with different Preferences to differ under ==")
+	}
+	if u0.Key() != u1.Key() {
+		t.Errorf("expected Keys to be equal: %v vs %v", u0.Key(), u1.Key())
+	}
+
+	m := map[Key]int{u0.Key(): 1}
+	if m[u1.Key()] != 1 {
+		t.Errorf("failed to look up Key %v", u1.Key())
+	}
+
+	checkEqual(t, "Key.UUID", true, u1, u1.Key().UUID())
+	checkPrefs(t, "Key.UUID", Text, DenseFirst, Dense, u1.Key().UUID())
+	checkString(t, "Key.String", u0.String(), u1.Key().String())
+
+	u2 := New()
+	if u0.Key() == u2.Key() {
+		t.Errorf("expected Keys of distinct UUIDs to differ")
+	}
+}
+
 func justBytes(ba []byte, _ error) []byte {
 	return ba
 }
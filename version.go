@@ -2,9 +2,10 @@ package uuid
 
 import (
 	"fmt"
+	"strings"
 )
 
-// Version indicates the RFC 4122-defined UUID version.
+// Version indicates the UUID version defined by RFC 4122 or RFC 9562.
 type Version byte
 
 // Version enum constants.
@@ -14,6 +15,9 @@ const (
 	V3 Version = 3
 	V4 Version = 4
 	V5 Version = 5
+	V6 Version = 6
+	V7 Version = 7
+	V8 Version = 8
 )
 
 var versionMap = map[Version]string{
@@ -22,11 +26,15 @@ var versionMap = map[Version]string{
 	V3: "V3",
 	V4: "V4",
 	V5: "V5",
+	V6: "V6",
+	V7: "V7",
+	V8: "V8",
 }
 
-// IsValid returns true iff the Version is one of the UUID versions known to RFC 4122.
+// IsValid returns true iff the Version is one of the UUID versions known to
+// RFC 4122 or RFC 9562.
 func (version Version) IsValid() bool {
-	return (version >= V1 && version <= V5)
+	return (version >= V1 && version <= V8)
 }
 
 func (version Version) String() string {
@@ -52,3 +60,44 @@ func (version *Version) FromString(in string) error {
 	}
 	return makeParseError("Version", "FromString", []byte(in), true)
 }
+
+// VersionSet is a set of Versions, for use with Parser.Versions.
+type VersionSet uint16
+
+// VersionsOf returns the set holding the given Versions.
+func VersionsOf(list ...Version) VersionSet {
+	var set VersionSet
+	for _, version := range list {
+		if version > 15 {
+			panic(fmt.Errorf("unknown value Version(%d)", version))
+		}
+		set |= 1 << version
+	}
+	return set
+}
+
+// Has returns true iff version is in the set.
+func (set VersionSet) Has(version Version) bool {
+	return version <= 15 && (set&(1<<version)) != 0
+}
+
+// List returns the Versions in the set, in ascending order.
+func (set VersionSet) List() []Version {
+	var list []Version
+	for version := Version(0); version <= 15; version++ {
+		if set.Has(version) {
+			list = append(list, version)
+		}
+	}
+	return list
+}
+
+// String returns the Versions in the set separated by "|", e.g. "V1|V4".
+func (set VersionSet) String() string {
+	list := set.List()
+	names := make([]string, len(list))
+	for i, version := range list {
+		names[i] = version.String()
+	}
+	return strings.Join(names, "|")
+}
@@ -16,6 +16,10 @@ func TestVersion(t *testing.T) {
 		{V3, "V3", true},
 		{V4, "V4", true},
 		{V5, "V5", true},
+		{V6, "V6", true},
+		{V7, "V7", true},
+		{V8, "V8", true},
+		{9, "V9", false},
 		{0, "V0", false},
 		{42, "V42", false},
 		{255, "V255", false},
@@ -55,3 +59,13 @@ func TestVersion(t *testing.T) {
 		}
 	}
 }
+
+func TestVersionSet(t *testing.T) {
+	set := VersionsOf(V4, V1)
+	if !set.Has(V1) || !set.Has(V4) || set.Has(V2) || set.Has(42) {
+		t.Errorf("wrong Has for %v", set)
+	}
+	if str := set.String(); str != "V1|V4" {
+		t.Errorf("wrong String: expected %q, got %q", "V1|V4", str)
+	}
+}